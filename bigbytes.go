@@ -125,14 +125,51 @@ func humanateBigBytes(s, base *big.Int, sizes []string) string {
 
 }
 
+// bigSISizes and bigIECSizes are the unit names of BigBytes and
+// BigIBytes.
+var (
+	bigSISizes  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", "RB", "QB"}
+	bigIECSizes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB", "RiB", "QiB"}
+)
+
+// humanateBigBytesN is humanateBytes for big.Ints: it writes s with
+// the given number of digits, rounded as BytesN rounds them, but
+// without going through a float64.
+func humanateBigBytesN(s, base *big.Int, digits int, sizes []string) string {
+	if s.Cmp(ten) < 0 {
+		return fmt.Sprintf("%d B", s)
+	}
+	if digits < 1 {
+		digits = 1
+	}
+	mag, unit := 0, big.NewInt(1)
+	for mag < len(sizes)-1 && new(big.Int).Mul(unit, base).Cmp(s) <= 0 {
+		unit.Mul(unit, base)
+		mag++
+	}
+
+	// val is s/unit rounded half up to digits-1 decimal places, as
+	// k/10^(digits-1).
+	rounding := new(big.Int).Exp(ten, big.NewInt(int64(digits-1)), nil)
+	num := new(big.Int).Mul(s, rounding)
+	k := num.Add(num.Lsh(num, 1), unit)
+	k.Quo(k, new(big.Int).Lsh(unit, 1))
+	intDigits := len(new(big.Int).Quo(k, rounding).String())
+	decimals := digits - intDigits
+	if decimals < 0 {
+		decimals = 0
+	}
+	val := roundDecimal(movePoint(k.String(), 1-digits), decimals, RoundHalfUp)
+	return val + " " + sizes[mag]
+}
+
 // BigBytes produces a human readable representation of an SI size.
 //
 // See also: ParseBigBytes.
 //
 // BigBytes(82854982) -> 83 MB
 func BigBytes(s *big.Int) string {
-	sizes := []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", "RB", "QB"}
-	return humanateBigBytes(s, bigSIExp, sizes)
+	return humanateBigBytes(s, bigSIExp, bigSISizes)
 }
 
 // BigIBytes produces a human readable representation of an IEC size.
@@ -141,8 +178,7 @@ func BigBytes(s *big.Int) string {
 //
 // BigIBytes(82854982) -> 79 MiB
 func BigIBytes(s *big.Int) string {
-	sizes := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB", "RiB", "QiB"}
-	return humanateBigBytes(s, bigIECExp, sizes)
+	return humanateBigBytes(s, bigIECExp, bigIECSizes)
 }
 
 // ParseBigBytes parses a string representation of bytes into the number
@@ -153,6 +189,18 @@ func BigIBytes(s *big.Int) string {
 // ParseBigBytes("42 MB") -> 42000000, nil
 // ParseBigBytes("42 mib") -> 44040192, nil
 func ParseBigBytes(s string) (*big.Int, error) {
+	val, err := parseBigBytesExact(s)
+	if err != nil {
+		return nil, err
+	}
+	rv := &big.Int{}
+	rv.Div(val.Num(), val.Denom())
+	return rv, nil
+}
+
+// parseBigBytesExact works like ParseBigBytes, but returns the exact
+// number of bytes, which may not be whole.
+func parseBigBytesExact(s string) (*big.Rat, error) {
 	lastDigit := 0
 	hasComma := false
 	for _, r := range s {
@@ -179,10 +227,7 @@ func ParseBigBytes(s string) (*big.Int, error) {
 	extra := strings.ToLower(strings.TrimSpace(s[lastDigit:]))
 	if m, ok := bigBytesSizeTable[extra]; ok {
 		mv := (&big.Rat{}).SetInt(m)
-		val.Mul(val, mv)
-		rv := &big.Int{}
-		rv.Div(val.Num(), val.Denom())
-		return rv, nil
+		return val.Mul(val, mv), nil
 	}
 
 	return nil, fmt.Errorf("unhandled size name: %v", extra)
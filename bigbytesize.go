@@ -0,0 +1,240 @@
+package humanize
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BigByteSize is the big.Int counterpart of ByteSize, for sizes that
+// may not fit in a uint64.
+//
+// A BigByteSize is an immutable value: the arithmetic methods return a
+// new BigByteSize rather than modifying their receiver, so it can be
+// copied and embedded in structs freely.  The zero value is zero bytes.
+// Unlike a ByteSize, a BigByteSize may be negative, as the difference
+// of two sizes, and reads and writes itself with a minus sign.
+//
+// It implements flag.Value, encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler, json.Unmarshaler and
+// fmt.Formatter in the same way ByteSize does.
+//
+// See also: ParseBigBytes, BigBytes, BigIBytes.
+type BigByteSize struct {
+	n *big.Int
+}
+
+// NewBigByteSize returns a BigByteSize of n bytes.  n is copied.
+func NewBigByteSize(n *big.Int) BigByteSize {
+	return BigByteSize{new(big.Int).Set(n)}
+}
+
+// exactBigByteUnits is ordered from largest to smallest.
+var exactBigByteUnits = []struct {
+	size *big.Int
+	name string
+}{
+	{BigQiByte, "QiB"},
+	{BigQByte, "QB"},
+	{BigRiByte, "RiB"},
+	{BigRByte, "RB"},
+	{BigYiByte, "YiB"},
+	{BigYByte, "YB"},
+	{BigZiByte, "ZiB"},
+	{BigZByte, "ZB"},
+	{BigEiByte, "EiB"},
+	{BigEByte, "EB"},
+	{BigPiByte, "PiB"},
+	{BigPByte, "PB"},
+	{BigTiByte, "TiB"},
+	{BigTByte, "TB"},
+	{BigGiByte, "GiB"},
+	{BigGByte, "GB"},
+	{BigMiByte, "MiB"},
+	{BigMByte, "MB"},
+	{BigKiByte, "KiB"},
+	{BigKByte, "kB"},
+}
+
+// exactBigBytes is exactBytes for big.Ints.
+func exactBigBytes(s *big.Int) string {
+	val, unit := new(big.Int).Abs(s), "B"
+	if s.Sign() != 0 {
+		q, m := &big.Int{}, &big.Int{}
+		for _, u := range exactBigByteUnits {
+			q.QuoRem(s, u.size, m)
+			if m.Sign() == 0 && q.CmpAbs(val) < 0 {
+				val.Abs(q)
+				unit = u.name
+			}
+		}
+	}
+	if s.Sign() < 0 {
+		val.Neg(val)
+	}
+	return val.String() + " " + unit
+}
+
+// Int returns the size as a new big.Int.
+func (b BigByteSize) Int() *big.Int {
+	if b.n == nil {
+		return &big.Int{}
+	}
+	return new(big.Int).Set(b.n)
+}
+
+func (b BigByteSize) int() *big.Int {
+	if b.n == nil {
+		return &big.Int{}
+	}
+	return b.n
+}
+
+// Add returns b+o.
+func (b BigByteSize) Add(o BigByteSize) BigByteSize {
+	return BigByteSize{new(big.Int).Add(b.int(), o.int())}
+}
+
+// Sub returns b-o.
+func (b BigByteSize) Sub(o BigByteSize) BigByteSize {
+	return BigByteSize{new(big.Int).Sub(b.int(), o.int())}
+}
+
+// Mul returns b*n.
+func (b BigByteSize) Mul(n int64) BigByteSize {
+	return BigByteSize{new(big.Int).Mul(b.int(), big.NewInt(n))}
+}
+
+// Div returns b/n, truncated towards zero.  Div panics if n is zero.
+func (b BigByteSize) Div(n int64) BigByteSize {
+	return BigByteSize{new(big.Int).Quo(b.int(), big.NewInt(n))}
+}
+
+// Cmp compares b and o, returning -1, 0 or +1 as big.Int.Cmp does.
+func (b BigByteSize) Cmp(o BigByteSize) int {
+	return b.int().Cmp(o.int())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of b.
+func (b BigByteSize) Sign() int {
+	return b.int().Sign()
+}
+
+// String returns the exact size, e.g. "3 PiB".
+func (b BigByteSize) String() string {
+	return exactBigBytes(b.int())
+}
+
+// parseWholeBytes parses a size exactly, where ParseBytes goes through
+// a float64 and both it and ParseBigBytes drop any fraction of a byte,
+// and rejects sizes that are not a whole number of bytes.
+func parseWholeBytes(s string) (*big.Int, error) {
+	v, err := parseBigBytesExact(s)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsInt() {
+		return nil, fmt.Errorf("invalid size: %v", s)
+	}
+	return new(big.Int).Set(v.Num()), nil
+}
+
+// Set parses a size such as "3 PiB" as ParseBigBytes does.  It
+// implements flag.Value.
+//
+// A leading minus sign makes the size negative.  Sizes that are not a
+// whole number of bytes, such as "1.5 B", are an error, as they are
+// when decoding JSON numbers.
+func (b *BigByteSize) Set(s string) error {
+	in := strings.TrimSpace(s)
+	neg := strings.HasPrefix(in, "-")
+	v, err := parseWholeBytes(strings.TrimPrefix(in, "-"))
+	if err != nil {
+		return err
+	}
+	if neg {
+		v.Neg(v)
+	}
+	b.n = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b BigByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *BigByteSize) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// MarshalJSON implements json.Marshaler.  The size is encoded as a
+// string.
+func (b BigByteSize) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  Both strings such as
+// "3 PiB" and plain numbers of bytes are accepted, negative or not.
+func (b *BigByteSize) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	switch {
+	case s == "null":
+		return nil
+	case strings.HasPrefix(s, `"`):
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return b.Set(s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || !r.IsInt() {
+		return fmt.Errorf("invalid size: %v", s)
+	}
+	b.n = new(big.Int).Set(r.Num())
+	return nil
+}
+
+// bigBytesN writes s as BigBytes or BigIBytes do, or with the given
+// number of digits as BytesN and IBytesN do when digits is not
+// negative, keeping the sign of negative sizes.
+func bigBytesN(s *big.Int, digits int, iec bool) string {
+	base, sizes := bigSIExp, bigSISizes
+	if iec {
+		base, sizes = bigIECExp, bigIECSizes
+	}
+	sign := ""
+	if s.Sign() < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(s)
+	if digits < 0 {
+		return sign + humanateBigBytes(abs, base, sizes)
+	}
+	return sign + humanateBigBytesN(abs, base, digits, sizes)
+}
+
+// Format implements fmt.Formatter.
+//
+// %v, %s and %q use String, %d and the other integer verbs print the
+// plain number of bytes, %h prints BigBytes and %H prints BigIBytes.
+// With a precision, %h and %H print that many digits, rounded as
+// BytesN and IBytesN round them.  Negative sizes get a minus sign.
+func (b BigByteSize) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'h', 'H':
+		digits := -1
+		if p, ok := f.Precision(); ok {
+			digits = p
+		}
+		padFormat(f, bigBytesN(b.int(), digits, verb == 'H'))
+	case 'v', 's', 'q':
+		fmt.Fprintf(f, formatDirective(f, verb), b.String())
+	case 'd', 'x', 'X', 'o', 'O', 'b':
+		b.int().Format(f, verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(humanize.BigByteSize=%d)", verb, b.int())
+	}
+}
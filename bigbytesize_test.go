@@ -0,0 +1,148 @@
+package humanize
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestBigByteSizeString(t *testing.T) {
+	testList{
+		{"zero", BigByteSize{}.String(), "0 B"},
+		{"1234", NewBigByteSize(big.NewInt(1234)).String(), "1234 B"},
+		{"10MiB", NewBigByteSize(BigMiByte).Mul(10).String(), "10 MiB"},
+		{"3QB", NewBigByteSize(BigQByte).Mul(3).String(), "3 QB"},
+		{"5YiB", NewBigByteSize(BigYiByte).Mul(5).String(), "5 YiB"},
+		{"2048QiB", NewBigByteSize(BigQiByte).Mul(2048).String(), "2048 QiB"},
+		{"-2GB", NewBigByteSize(BigGByte).Mul(-2).String(), "-2 GB"},
+	}.validate(t)
+}
+
+func TestBigByteSizeArithmetic(t *testing.T) {
+	pb := NewBigByteSize(BigPByte)
+	total := pb.Mul(1500).Add(pb).Sub(NewBigByteSize(BigTByte))
+	testList{
+		{"total", total.String(), "1500999 TB"},
+		{"div", pb.Mul(3).Div(2).String(), "1500 TB"},
+		{"unchanged", pb.String(), "1 PB"},
+	}.validate(t)
+
+	if pb.Cmp(total) >= 0 || total.Cmp(pb) <= 0 || pb.Cmp(pb) != 0 {
+		t.Errorf("Bad comparison of %v and %v", pb, total)
+	}
+	if pb.Sign() != 1 || (BigByteSize{}).Sign() != 0 {
+		t.Errorf("Bad signs")
+	}
+	n := pb.Int()
+	n.SetInt64(0)
+	if pb.Sign() == 0 {
+		t.Errorf("Int exposed internal state")
+	}
+}
+
+func TestBigByteSizeRoundTrip(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890123", 10)
+	for _, v := range []*big.Int{big.NewInt(0), big.NewInt(999), BigKiByte, BigEiByte, BigQByte, huge} {
+		b := NewBigByteSize(v)
+		var got BigByteSize
+		if err := got.Set(b.String()); err != nil {
+			t.Errorf("Error parsing %v: %v", b, err)
+			continue
+		}
+		if got.Cmp(b) != 0 {
+			t.Errorf("Round trip of %v gave %v", v, got.Int())
+		}
+	}
+}
+
+func TestBigByteSizeNegativeRoundTrip(t *testing.T) {
+	neg := NewBigByteSize(big.NewInt(5)).Sub(NewBigByteSize(BigKiByte))
+	for _, b := range []BigByteSize{neg, NewBigByteSize(big.NewInt(-5)), NewBigByteSize(new(big.Int).Neg(BigPiByte))} {
+		var got BigByteSize
+		if err := got.Set(b.String()); err != nil || got.Cmp(b) != 0 {
+			t.Errorf("Round trip of %v gave %v, %v", b, got, err)
+		}
+
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("Error encoding %v: %v", b, err)
+		}
+		var decoded BigByteSize
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.Cmp(b) != 0 {
+			t.Errorf("JSON round trip of %v through %s gave %v, %v", b, data, decoded, err)
+		}
+	}
+
+	var b BigByteSize
+	if err := json.Unmarshal([]byte(`-1024`), &b); err != nil || b.Cmp(NewBigByteSize(big.NewInt(-1024))) != 0 {
+		t.Errorf("Decoding -1024 gave %v, %v", b, err)
+	}
+	if err := b.Set("- 5 B"); err == nil {
+		t.Errorf("Expected error parsing a detached sign, got %v", b)
+	}
+}
+
+func TestBigByteSizeJSON(t *testing.T) {
+	var usage struct {
+		Total BigByteSize `json:"total"`
+		Quota BigByteSize `json:"quota"`
+	}
+	in := `{"total": "2.5 ZiB", "quota": 1000000000000000000000000}`
+	if err := json.Unmarshal([]byte(in), &usage); err != nil {
+		t.Fatalf("Error decoding %s: %v", in, err)
+	}
+	out, err := json.Marshal(usage)
+	if err != nil {
+		t.Fatalf("Error encoding: %v", err)
+	}
+	exp := `{"total":"2560 EiB","quota":"1 YB"}`
+	if string(out) != exp {
+		t.Errorf("Expected %s, got %s", exp, out)
+	}
+
+	for _, bad := range []string{`1.5`, `"12 JB"`, `true`, `"1.5 B"`, `"0.5 B"`, `"1.0001 kB"`} {
+		var b BigByteSize
+		if err := json.Unmarshal([]byte(bad), &b); err == nil {
+			t.Errorf("Expected error decoding %s, got %v", bad, b)
+		}
+	}
+}
+
+func TestBigByteSizeFormat(t *testing.T) {
+	b := NewBigByteSize(big.NewInt(82854982))
+	yotta, _ := new(big.Int).SetString("123456789012345678901234567", 10)
+	testList{
+		{"%v", fmt.Sprintf("%v", b), "82854982 B"},
+		{"%d", fmt.Sprintf("%d", b), "82854982"},
+		{"%x", fmt.Sprintf("%x", b), "4f04446"},
+		{"%h", fmt.Sprintf("%h", b), "83 MB"},
+		{"%H", fmt.Sprintf("%H", b), "79 MiB"},
+		{"%.4h", fmt.Sprintf("%.4h", b), "82.86 MB"},
+		{"%.3H", fmt.Sprintf("%.3H", b), "79.0 MiB"},
+		{"%h small", fmt.Sprintf("%h", NewBigByteSize(big.NewInt(9))), "9 B"},
+		{"%h negative", fmt.Sprintf("%h", NewBigByteSize(big.NewInt(-82854982))), "-83 MB"},
+		{"%.4H negative", fmt.Sprintf("%.4H", NewBigByteSize(big.NewInt(-82854982))), "-79.02 MiB"},
+		{"%h matches BigBytes", fmt.Sprintf("%h", NewBigByteSize(yotta)), BigBytes(yotta)},
+		{"%H matches BigIBytes", fmt.Sprintf("%H", NewBigByteSize(yotta)), BigIBytes(yotta)},
+		{"%.2h yotta", fmt.Sprintf("%.2h", NewBigByteSize(yotta)), "124 YB"},
+		{"%.5h yotta", fmt.Sprintf("%.5h", NewBigByteSize(yotta)), "123.46 YB"},
+		{"%h huge", fmt.Sprintf("%h", NewBigByteSize(new(big.Int).Mul(BigQByte, big.NewInt(2000)))), "2000 QB"},
+		{"%-8H|", fmt.Sprintf("%-8H|", b), "79 MiB  |"},
+		{"%z", fmt.Sprintf("%z", b), "%!z(humanize.BigByteSize=82854982)"},
+	}.validate(t)
+}
+
+func TestBigByteSizeSetFraction(t *testing.T) {
+	var b BigByteSize
+	for _, s := range []string{"1.5 kB", "0.5 KiB", "2.0 B"} {
+		if err := b.Set(s); err != nil {
+			t.Errorf("Error parsing %q: %v", s, err)
+		}
+	}
+	for _, bad := range []string{"1.5 B", "0.5 B", "1.0001 kB"} {
+		if err := b.Set(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, b)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package humanize
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes that reads and writes itself in human
+// readable form.
+//
+// It implements flag.Value, encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler, json.Unmarshaler and
+// fmt.Formatter, so it can be used directly for command line flags
+// and configuration fields:
+//
+//	var maxBody humanize.ByteSize
+//	flag.Var(&maxBody, "max-body", "maximum request body size")
+//
+// Text and JSON encoding is exact, picking the largest SI or IEC unit
+// that divides the size evenly (e.g. "10 MiB", "2 GB", "1234 B").
+// When formatting, the %h and %H verbs produce the rounded SI and IEC
+// forms of Bytes and IBytes, with the precision selecting the number
+// of digits as in BytesN and IBytesN.
+//
+// See also: ParseBytes, Bytes, IBytes.
+type ByteSize uint64
+
+// exactByteUnits is ordered from largest to smallest.
+var exactByteUnits = []struct {
+	size uint64
+	name string
+}{
+	{EiByte, "EiB"},
+	{EByte, "EB"},
+	{PiByte, "PiB"},
+	{PByte, "PB"},
+	{TiByte, "TiB"},
+	{TByte, "TB"},
+	{GiByte, "GiB"},
+	{GByte, "GB"},
+	{MiByte, "MiB"},
+	{MByte, "MB"},
+	{KiByte, "KiB"},
+	{KByte, "kB"},
+}
+
+// exactBytes renders s without any loss of precision, using whichever
+// unit gives the smallest whole number.
+func exactBytes(s uint64) string {
+	val, unit := s, "B"
+	if s != 0 {
+		for _, u := range exactByteUnits {
+			if s%u.size == 0 && s/u.size < val {
+				val, unit = s/u.size, u.name
+			}
+		}
+	}
+	return strconv.FormatUint(val, 10) + " " + unit
+}
+
+// String returns the exact size, e.g. "10 MiB".
+func (b ByteSize) String() string {
+	return exactBytes(uint64(b))
+}
+
+// Set parses a size such as "10MiB" or "2 GB" as ParseBytes does.  It
+// implements flag.Value.
+//
+// Sizes that are not a whole number of bytes, such as "1.5 B", are an
+// error, as they are when decoding JSON numbers.
+func (b *ByteSize) Set(s string) error {
+	v, err := parseWholeBytes(s)
+	if err != nil {
+		return err
+	}
+	if v.Sign() < 0 || !v.IsUint64() {
+		return fmt.Errorf("too large: %v", s)
+	}
+	*b = ByteSize(v.Uint64())
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// MarshalJSON implements json.Marshaler.  The size is encoded as a
+// string.
+func (b ByteSize) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  Both strings such as
+// "2 GB" and plain numbers of bytes are accepted.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	switch {
+	case s == "null":
+		return nil
+	case strings.HasPrefix(s, `"`):
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return b.Set(s)
+	}
+	v, err := parseJSONUint(s)
+	if err != nil {
+		return err
+	}
+	*b = ByteSize(v)
+	return nil
+}
+
+// parseJSONUint parses a JSON number that must hold a non-negative
+// whole value.
+func parseJSONUint(s string) (uint64, error) {
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if f < 0 || f != float64(uint64(f)) {
+		return 0, fmt.Errorf("invalid size: %v", s)
+	}
+	return uint64(f), nil
+}
+
+// Format implements fmt.Formatter.
+//
+// %v, %s and %q use String, %d and the other integer verbs print the
+// plain number of bytes, %h prints Bytes and %H prints IBytes.
+func (b ByteSize) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'h', 'H':
+		digits := 2
+		if p, ok := f.Precision(); ok {
+			digits = p
+		}
+		if verb == 'h' {
+			padFormat(f, BytesN(uint64(b), digits))
+		} else {
+			padFormat(f, IBytesN(uint64(b), digits))
+		}
+	case 'v', 's', 'q':
+		fmt.Fprintf(f, formatDirective(f, verb), b.String())
+	case 'd', 'x', 'X', 'o', 'O', 'b':
+		fmt.Fprintf(f, formatDirective(f, verb), uint64(b))
+	default:
+		fmt.Fprintf(f, "%%!%c(humanize.ByteSize=%d)", verb, uint64(b))
+	}
+}
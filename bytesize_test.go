@@ -0,0 +1,120 @@
+package humanize
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestByteSizeString(t *testing.T) {
+	testList{
+		{"0", ByteSize(0).String(), "0 B"},
+		{"1", ByteSize(1).String(), "1 B"},
+		{"1234", ByteSize(1234).String(), "1234 B"},
+		{"1000", ByteSize(1000).String(), "1 kB"},
+		{"1024", ByteSize(1024).String(), "1 KiB"},
+		{"1024000", ByteSize(1024000).String(), "1000 KiB"},
+		{"10MiB", ByteSize(10 * MiByte).String(), "10 MiB"},
+		{"2GB", ByteSize(2 * GByte).String(), "2 GB"},
+		{"1500kB", ByteSize(1500 * KByte).String(), "1500 kB"},
+		{"16EiB-1", ByteSize(1<<64 - 1).String(), "18446744073709551615 B"},
+	}.validate(t)
+}
+
+func TestByteSizeRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 999, 1000, 1024, 82854982, 10 * MiByte, 5 * EiByte, 1<<64 - 1} {
+		b := ByteSize(v)
+		var got ByteSize
+		if err := got.Set(b.String()); err != nil {
+			t.Errorf("Error parsing %v: %v", b, err)
+			continue
+		}
+		if got != b {
+			t.Errorf("Round trip of %d gave %d", v, uint64(got))
+		}
+	}
+}
+
+func TestByteSizeFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	size := ByteSize(MiByte)
+	fs.Var(&size, "max-body", "maximum body size")
+	if err := fs.Parse([]string{"--max-body=10MiB"}); err != nil {
+		t.Fatalf("Error parsing flags: %v", err)
+	}
+	if size != 10*MiByte {
+		t.Errorf("Expected 10 MiB, got %v", size)
+	}
+	if err := fs.Parse([]string{"--max-body=lots"}); err == nil {
+		t.Errorf("Expected error parsing a bogus size")
+	}
+}
+
+func TestByteSizeJSON(t *testing.T) {
+	var cfg struct {
+		CacheSize ByteSize `json:"cache_size"`
+		MaxBody   ByteSize `json:"max_body"`
+		Unset     ByteSize `json:"unset"`
+	}
+	in := `{"cache_size": "2 GB", "max_body": 1048576, "unset": null}`
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatalf("Error decoding %s: %v", in, err)
+	}
+	if cfg.CacheSize != 2*GByte {
+		t.Errorf("Expected 2 GB cache, got %v", cfg.CacheSize)
+	}
+	if cfg.MaxBody != MiByte {
+		t.Errorf("Expected 1 MiB body, got %v", cfg.MaxBody)
+	}
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Error encoding: %v", err)
+	}
+	exp := `{"cache_size":"2 GB","max_body":"1 MiB","unset":"0 B"}`
+	if string(out) != exp {
+		t.Errorf("Expected %s, got %s", exp, out)
+	}
+
+	for _, bad := range []string{`-1`, `1.5`, `"12 JB"`, `true`, `"1.5 B"`, `"0.5 B"`, `"1.0001 kB"`} {
+		var b ByteSize
+		if err := json.Unmarshal([]byte(bad), &b); err == nil {
+			t.Errorf("Expected error decoding %s, got %v", bad, b)
+		}
+	}
+}
+
+func TestByteSizeFormat(t *testing.T) {
+	b := ByteSize(82854982)
+	testList{
+		{"%v", fmt.Sprintf("%v", b), "82854982 B"},
+		{"%s", fmt.Sprintf("%s", ByteSize(GiByte)), "1 GiB"},
+		{"%q", fmt.Sprintf("%q", ByteSize(GiByte)), `"1 GiB"`},
+		{"%d", fmt.Sprintf("%d", b), "82854982"},
+		{"%12d", fmt.Sprintf("%12d", b), "    82854982"},
+		{"%h", fmt.Sprintf("%h", b), "83 MB"},
+		{"%H", fmt.Sprintf("%H", b), "79 MiB"},
+		{"%.4h", fmt.Sprintf("%.4h", b), "82.86 MB"},
+		{"%.4H", fmt.Sprintf("%.4H", b), "79.02 MiB"},
+		{"%8h", fmt.Sprintf("%8h", b), "   83 MB"},
+		{"%-8h|", fmt.Sprintf("%-8h|", b), "83 MB   |"},
+		{"%z", fmt.Sprintf("%z", b), "%!z(humanize.ByteSize=82854982)"},
+	}.validate(t)
+}
+
+func TestByteSizeSetFraction(t *testing.T) {
+	var b ByteSize
+	for _, s := range []string{"1.5 kB", "0.5 KiB", "2.0 B"} {
+		if err := b.Set(s); err != nil {
+			t.Errorf("Error parsing %q: %v", s, err)
+		}
+	}
+	for _, bad := range []string{"1.5 B", "0.5 B", "1.0001 kB"} {
+		if err := b.Set(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, b)
+		}
+	}
+}
@@ -62,15 +62,20 @@ func Comma(v int64) string {
 //
 // e.g. Commaf(834142.32) -> 834,142.32
 func Commaf(v float64) string {
+	return commaDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// commaDecimal inserts commas into the plain decimal number s.
+func commaDecimal(s string) string {
 	buf := &bytes.Buffer{}
-	if v < 0 {
+	if strings.HasPrefix(s, "-") {
 		buf.Write([]byte{'-'})
-		v = 0 - v
+		s = s[1:]
 	}
 
 	comma := []byte{','}
 
-	parts := strings.Split(strconv.FormatFloat(v, 'f', -1, 64), ".")
+	parts := strings.Split(s, ".")
 	pos := 0
 	if len(parts[0])%3 != 0 {
 		pos += len(parts[0]) % 3
@@ -93,9 +98,25 @@ func Commaf(v float64) string {
 // CommafWithDigits works like the Commaf but limits the resulting
 // string to the given number of decimal places.
 //
+// The value is rounded to nearest, with ties to even, and trailing
+// zeros are removed.
+//
+// See also: CommafWithRounding.
+//
 // e.g. CommafWithDigits(834142.32, 1) -> 834,142.3
 func CommafWithDigits(f float64, decimals int) string {
-	return stripTrailingDigits(Commaf(f), decimals)
+	return CommafWithRounding(f, decimals, RoundHalfEven)
+}
+
+// CommafWithRounding works like CommafWithDigits, rounding the value
+// with the given mode.
+//
+// e.g. CommafWithRounding(834142.35, 1, RoundHalfUp) -> 834,142.4
+func CommafWithRounding(f float64, decimals int, mode RoundingMode) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return commaDecimal(FtoaWithRounding(f, decimals, mode))
 }
 
 // BigComma produces a string form of the given big.Int in base 10
@@ -129,3 +150,24 @@ func BigComma(bin *big.Int) string {
 	parts[j] = strconv.Itoa(int(b.Int64()))
 	return sign + strings.Join(parts[j:], ",")
 }
+
+// ParseComma parses a whole number as Comma writes it.  Commas are
+// optional, but must separate groups of three digits.
+//
+// e.g. ParseComma("834,142") -> 834142, nil
+func ParseComma(s string) (int64, error) {
+	return groupedNumberFormat.ParseInt(s)
+}
+
+// ParseCommaf parses a number as Commaf writes it.
+//
+// e.g. ParseCommaf("1,234,567.89") -> 1234567.89, nil
+func ParseCommaf(s string) (float64, error) {
+	return groupedNumberFormat.ParseFloat(s)
+}
+
+// ParseBigComma parses a whole number of any size as BigComma writes
+// it.
+func ParseBigComma(s string) (*big.Int, error) {
+	return groupedNumberFormat.ParseBigInt(s)
+}
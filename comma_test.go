@@ -3,6 +3,7 @@ package humanize
 import (
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -42,6 +43,12 @@ func TestCommafWithDigits(t *testing.T) {
 		{"1.23, 1", CommafWithDigits(1.23, 1), "1.2"},
 		{"1.23, 2", CommafWithDigits(1.23, 2), "1.23"},
 		{"1.23, 3", CommafWithDigits(1.23, 3), "1.23"},
+		{"834142.326, 2", CommafWithDigits(834142.326, 2), "834,142.33"},
+		{"999999.996, 2", CommafWithDigits(999999.996, 2), "1,000,000"},
+		{"-1234.5, 0", CommafWithDigits(-1234.5, 0), "-1,234"},
+		{"1e-7, 7", CommafWithDigits(1e-7, 7), "0.0000001"},
+		{"half up", CommafWithRounding(834142.35, 1, RoundHalfUp), "834,142.4"},
+		{"toward zero", CommafWithRounding(834142.39, 1, RoundTowardZero), "834,142.3"},
 	}.validate(t)
 }
 
@@ -154,3 +161,44 @@ func TestHumanizeBigIntMutation(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestParseComma(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp int64
+	}{
+		{"0", 0},
+		{"834,142", 834142},
+		{"-1,234,567", -1234567},
+		{"1234567", 1234567},
+		{" 1,000 ", 1000},
+		{"9,223,372,036,854,775,807", math.MaxInt64},
+	}
+	for _, test := range tests {
+		got, err := ParseComma(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", "1,23", "12,34,567", ",123", "1,234,", "1,,234", "1.5", "1,0000", "9,223,372,036,854,775,808", "abc"} {
+		if got, err := ParseComma(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestParseBigComma(t *testing.T) {
+	for _, s := range []string{"0", "-1,000", "1,000,000,000,000,000,000,000,000,000,001"} {
+		n, _ := new(big.Int).SetString(strings.Replace(s, ",", "", -1), 10)
+		got, err := ParseBigComma(s)
+		if err != nil || got.Cmp(n) != 0 || BigComma(got) != s {
+			t.Errorf("ParseBigComma(%q) = %v, %v", s, got, err)
+		}
+	}
+	if got, err := ParseBigComma("1,000.5"); err == nil {
+		t.Errorf("Expected error parsing a fraction, got %v", got)
+	}
+}
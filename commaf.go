@@ -39,3 +39,8 @@ func BigCommaf(v *big.Float) string {
 	}
 	return buf.String()
 }
+
+// ParseBigCommaf parses a number as BigCommaf writes it.
+func ParseBigCommaf(s string) (*big.Float, error) {
+	return groupedNumberFormat.ParseBigFloat(s)
+}
@@ -43,3 +43,41 @@ func TestBigCommafs(t *testing.T) {
 		{"-10", BigCommaf(big.NewFloat(-10)), "-10"},
 	}.validate(t)
 }
+
+func TestParseCommaf(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp float64
+	}{
+		{"1,234,567.89", 1234567.89},
+		{"-1,234.5", -1234.5},
+		{"0.25", 0.25},
+		{".5", 0.5},
+		{"1234.5", 1234.5},
+	}
+	for _, test := range tests {
+		got, err := ParseCommaf(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", ".", "1.", "1,23.4", "1.234,5", "1.2.3", "--1"} {
+		if got, err := ParseCommaf(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestParseBigCommaf(t *testing.T) {
+	s := "123,456,789,012,345,678,901,234,567.890123"
+	got, err := ParseBigCommaf(s)
+	if err != nil {
+		t.Fatalf("Error parsing %q: %v", s, err)
+	}
+	if BigCommaf(got) != s {
+		t.Errorf("Round trip of %q gave %q", s, BigCommaf(got))
+	}
+}
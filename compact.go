@@ -0,0 +1,124 @@
+package humanize
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompactLocale holds the abbreviations and separators Compact uses.
+type CompactLocale struct {
+	// Suffixes maps powers of ten to their abbreviations, such as
+	// 6 to "M".
+	Suffixes map[int]string
+	// Decimal and Group are the decimal and digit group separators,
+	// "." and "," when empty.
+	Decimal, Group string
+	// Separator goes between the number and the abbreviation.
+	Separator string
+}
+
+// CompactEnglish is the default CompactLocale: 1.2K, 3.4M, 5B, 7T.
+var CompactEnglish = CompactLocale{
+	Suffixes: map[int]string{3: "K", 6: "M", 9: "B", 12: "T"},
+	Decimal:  ".",
+	Group:    ",",
+}
+
+// CompactOptions controls how CompactWithOptions formats a value.  The
+// zero value uses CompactEnglish and keeps all the digits of the
+// value, like SIOptions.
+type CompactOptions struct {
+	// Precision, Digits and Rounding round the value as in
+	// SIOptions.
+	Precision SIPrecision
+	Digits    int
+	Rounding  RoundingMode
+
+	// Lowercase writes the abbreviations in lower case (1.2k, 3.4m,
+	// 5b).
+	Lowercase bool
+
+	// Locale replaces CompactEnglish when not nil.
+	Locale *CompactLocale
+}
+
+// defaultCompactOptions are used by Compact, Compactf and BigCompact.
+var defaultCompactOptions = CompactOptions{Precision: SIDecimals, Digits: 1}
+
+// compact formats the plain decimal number s in compact notation.
+func compact(s string, opts CompactOptions) string {
+	locale := opts.Locale
+	if locale == nil {
+		locale = &CompactEnglish
+	}
+	exponents := []int{0}
+	for e := range locale.Suffixes {
+		if e > 0 {
+			exponents = append(exponents, e)
+		}
+	}
+	sort.Ints(exponents)
+
+	v, i := scaleDecimal(s, exponents, opts.Precision, opts.Digits, opts.Rounding)
+	decimal, group := ".", ","
+	if locale.Decimal != "" {
+		decimal = locale.Decimal
+	}
+	if locale.Group != "" {
+		group = locale.Group
+	}
+	v = strings.NewReplacer(",", group, ".", decimal).Replace(commaDecimal(v))
+	if i == 0 {
+		return v
+	}
+	suffix := locale.Suffixes[exponents[i]]
+	if opts.Lowercase {
+		suffix = strings.ToLower(suffix)
+	}
+	return v + locale.Separator + suffix
+}
+
+// Compact writes a count in compact notation with one decimal place,
+// as social sites show follower and view counts.
+//
+// See also: CompactWithOptions.
+//
+// e.g. Compact(1234) -> 1.2K
+// e.g. Compact(3400000) -> 3.4M
+// e.g. Compact(999999) -> 1M
+func Compact(n int64) string {
+	return compact(strconv.FormatInt(n, 10), defaultCompactOptions)
+}
+
+// Compactf works like Compact for floats.
+func Compactf(f float64) string {
+	return CompactWithOptions(f, defaultCompactOptions)
+}
+
+// BigCompact works like Compact for big integers.  Values past the
+// largest abbreviation keep it, as in "1,000T".
+func BigCompact(n *big.Int) string {
+	return BigCompactWithOptions(n, defaultCompactOptions)
+}
+
+// CompactWithOptions writes a number in compact notation as opts
+// asks.
+//
+// e.g. CompactWithOptions(5e9, CompactOptions{Lowercase: true}) -> 5b
+// e.g. CompactWithOptions(1299, CompactOptions{Precision: SIDecimals, Digits: 1, Rounding: RoundTowardZero}) -> 1.2K
+func CompactWithOptions(f float64, opts CompactOptions) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return s
+	}
+	return compact(s, opts)
+}
+
+// BigCompactWithOptions works like CompactWithOptions for big
+// integers.
+func BigCompactWithOptions(n *big.Int, opts CompactOptions) string {
+	return compact(n.String(), opts)
+}
@@ -0,0 +1,54 @@
+package humanize
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	huge, _ := new(big.Int).SetString("1234000000000000", 10)
+	testList{
+		{"small", Compact(999), "999"},
+		{"zero", Compact(0), "0"},
+		{"K", Compact(1234), "1.2K"},
+		{"tens K", Compact(12345), "12.3K"},
+		{"M", Compact(3400000), "3.4M"},
+		{"B", Compact(5000000000), "5B"},
+		{"T", Compact(7e12), "7T"},
+		{"negative", Compact(-1500), "-1.5K"},
+		{"never 1000K", Compact(999999), "1M"},
+		{"never 1000", Compact(999960), "1M"},
+		{"float", Compactf(1234.5), "1.2K"},
+		{"float fraction", Compactf(12.34), "12.3"},
+		{"big", BigCompact(big.NewInt(2500000)), "2.5M"},
+		{"past T", BigCompact(huge), "1,234T"},
+	}.validate(t)
+}
+
+func TestCompactWithOptions(t *testing.T) {
+	german := CompactLocale{
+		Suffixes:  map[int]string{3: "Tsd.", 6: "Mio.", 9: "Mrd."},
+		Decimal:   ",",
+		Group:     ".",
+		Separator: " ",
+	}
+	suffixesOnly := CompactLocale{Suffixes: map[int]string{3: "k", 6: "M"}}
+	testList{
+		{"lowercase", CompactWithOptions(5e9, CompactOptions{Lowercase: true}), "5b"},
+		{"lowercase k", CompactWithOptions(1200, CompactOptions{Lowercase: true}), "1.2k"},
+		{"shortest", CompactWithOptions(1234567, CompactOptions{}), "1.234567M"},
+		{"truncate", CompactWithOptions(1299, CompactOptions{Precision: SIDecimals, Digits: 1, Rounding: RoundTowardZero}), "1.2K"},
+		{"truncate 999999", CompactWithOptions(999999, CompactOptions{Precision: SIDecimals, Digits: 1, Rounding: RoundTowardZero}), "999.9K"},
+		{"significant", CompactWithOptions(123456, CompactOptions{Precision: SISignificant, Digits: 2}), "120K"},
+		{"significant carry", CompactWithOptions(999500, CompactOptions{Precision: SISignificant, Digits: 3}), "1.00M"},
+		{"locale", CompactWithOptions(3.46e6, CompactOptions{Precision: SIDecimals, Digits: 1, Locale: &german}), "3,5 Mio."},
+		{"locale group", CompactWithOptions(1.5e12, CompactOptions{Locale: &german}), "1.500 Mrd."},
+		{"locale small", CompactWithOptions(12.5, CompactOptions{Locale: &german}), "12,5"},
+		{"locale no separators", CompactWithOptions(1234, CompactOptions{Precision: SIDecimals, Digits: 1, Locale: &suffixesOnly}), "1.2k"},
+		{"locale no separators M", CompactWithOptions(1234567, CompactOptions{Precision: SIDecimals, Digits: 1, Locale: &suffixesOnly}), "1.2M"},
+		{"locale no separators group", CompactWithOptions(1.5e12, CompactOptions{Locale: &suffixesOnly}), "1,500,000M"},
+		{"inf", Compactf(math.Inf(-1)), "-Inf"},
+		{"big options", BigCompactWithOptions(big.NewInt(1234), CompactOptions{Precision: SIFixed, Digits: 2, Lowercase: true}), "1.23k"},
+	}.validate(t)
+}
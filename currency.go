@@ -0,0 +1,245 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Currency describes how amounts of an ISO 4217 currency are written.
+type Currency struct {
+	// Code is the ISO 4217 code, such as "USD".
+	Code string
+	// Symbol is written with the amount, such as "$".
+	Symbol string
+	// MinorUnits is the number of decimal places, such as 2 for
+	// cents, 0 for yen or 3 for fils.
+	MinorUnits int
+	// SymbolAfter writes the symbol after the amount.
+	SymbolAfter bool
+}
+
+// currencies are the ISO 4217 currencies LookupCurrency knows, with
+// the symbols used in English text.
+var currencies = map[string]Currency{
+	"AED": {"AED", "AED", 2, false},
+	"ARS": {"ARS", "AR$", 2, false},
+	"AUD": {"AUD", "A$", 2, false},
+	"BHD": {"BHD", "BD", 3, false},
+	"BRL": {"BRL", "R$", 2, false},
+	"CAD": {"CAD", "CA$", 2, false},
+	"CHF": {"CHF", "CHF", 2, false},
+	"CLP": {"CLP", "CLP$", 0, false},
+	"CNY": {"CNY", "CN¥", 2, false},
+	"COP": {"COP", "COL$", 2, false},
+	"CZK": {"CZK", "Kč", 2, true},
+	"DKK": {"DKK", "kr.", 2, true},
+	"EGP": {"EGP", "E£", 2, false},
+	"EUR": {"EUR", "€", 2, false},
+	"GBP": {"GBP", "£", 2, false},
+	"HKD": {"HKD", "HK$", 2, false},
+	"HUF": {"HUF", "Ft", 2, true},
+	"IDR": {"IDR", "Rp", 2, false},
+	"ILS": {"ILS", "₪", 2, false},
+	"INR": {"INR", "₹", 2, false},
+	"IQD": {"IQD", "IQD", 3, false},
+	"ISK": {"ISK", "kr", 0, true},
+	"JOD": {"JOD", "JD", 3, false},
+	"JPY": {"JPY", "¥", 0, false},
+	"KES": {"KES", "KSh", 2, false},
+	"KRW": {"KRW", "₩", 0, false},
+	"KWD": {"KWD", "KD", 3, false},
+	"LYD": {"LYD", "LD", 3, false},
+	"MXN": {"MXN", "MX$", 2, false},
+	"MYR": {"MYR", "RM", 2, false},
+	"NGN": {"NGN", "₦", 2, false},
+	"NOK": {"NOK", "kr", 2, true},
+	"NZD": {"NZD", "NZ$", 2, false},
+	"OMR": {"OMR", "OMR", 3, false},
+	"PHP": {"PHP", "₱", 2, false},
+	"PKR": {"PKR", "Rs", 2, false},
+	"PLN": {"PLN", "zł", 2, true},
+	"RUB": {"RUB", "₽", 2, true},
+	"SAR": {"SAR", "SAR", 2, false},
+	"SEK": {"SEK", "kr", 2, true},
+	"SGD": {"SGD", "S$", 2, false},
+	"THB": {"THB", "฿", 2, false},
+	"TND": {"TND", "DT", 3, false},
+	"TRY": {"TRY", "₺", 2, false},
+	"TWD": {"TWD", "NT$", 2, false},
+	"UAH": {"UAH", "₴", 2, false},
+	"UGX": {"UGX", "USh", 0, false},
+	"USD": {"USD", "$", 2, false},
+	"VND": {"VND", "₫", 0, true},
+	"XAF": {"XAF", "FCFA", 0, true},
+	"XOF": {"XOF", "CFA", 0, true},
+	"ZAR": {"ZAR", "R", 2, false},
+}
+
+// LookupCurrency returns the currency with the given ISO 4217 code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unknown currency code: %q", code)
+	}
+	return c, nil
+}
+
+// CurrencyOptions controls how MoneyWithOptions writes an amount.
+// The zero value writes "-$1,234.00".
+type CurrencyOptions struct {
+	// Accounting writes negative amounts in parentheses, as in
+	// "($1,234.00)".
+	Accounting bool
+	// Code writes the ISO 4217 code instead of the symbol, as in
+	// "USD 1,234.00".
+	Code bool
+	// NoSymbol writes the amount alone, as in "1,234.00".
+	NoSymbol bool
+	// Rounding rounds the amount to the currency's minor units.
+	Rounding RoundingMode
+	// Decimal and Group replace the "." and "," separators when set.
+	Decimal, Group string
+}
+
+// formatMoney writes the plain decimal number s as an amount of c.
+func formatMoney(s string, c Currency, opts CurrencyOptions) string {
+	v := roundDecimal(s, c.MinorUnits, opts.Rounding)
+	neg := strings.HasPrefix(v, "-")
+	v = commaDecimal(strings.TrimPrefix(v, "-"))
+	if opts.Decimal != "" || opts.Group != "" {
+		decimal, group := ".", ","
+		if opts.Decimal != "" {
+			decimal = opts.Decimal
+		}
+		if opts.Group != "" {
+			group = opts.Group
+		}
+		v = strings.NewReplacer(".", decimal, ",", group).Replace(v)
+	}
+
+	symbol := c.Symbol
+	switch {
+	case opts.NoSymbol:
+		symbol = ""
+	case opts.Code:
+		symbol = c.Code
+	}
+	// Symbols after the amount, and symbols before it ending in a
+	// letter, are spaced from it: "10.00 kr", "CHF 10.00", "$10.00".
+	if symbol != "" {
+		if c.SymbolAfter {
+			v += " " + symbol
+		} else {
+			r, _ := utf8.DecodeLastRuneInString(symbol)
+			if unicode.IsLetter(r) {
+				symbol += " "
+			}
+			v = symbol + v
+		}
+	}
+
+	if neg {
+		if opts.Accounting {
+			return "(" + v + ")"
+		}
+		return "-" + v
+	}
+	return v
+}
+
+// Money writes an amount of the currency with the given ISO 4217
+// code, rounded to its minor units, with its symbol.  Unknown codes
+// are written after the amount, with two decimal places.
+//
+// See also: MoneyWithOptions, BigMoney, RatMoney.
+//
+// e.g. Money(1234.5, "USD") -> $1,234.50
+// e.g. Money(1234.5, "JPY") -> ¥1,234
+// e.g. Money(-1.2345, "KWD") -> -KD 1.234
+func Money(amount float64, code string) string {
+	return MoneyWithOptions(amount, moneyCurrency(code), CurrencyOptions{})
+}
+
+// moneyCurrency looks up code, falling back to a currency written
+// with its code.
+func moneyCurrency(code string) Currency {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return Currency{Code: code, Symbol: code, MinorUnits: 2, SymbolAfter: true}
+	}
+	return c
+}
+
+// MoneyWithOptions writes an amount of the currency as opts asks.
+//
+// Given usd, _ := LookupCurrency("USD"):
+//
+// e.g. MoneyWithOptions(-1234, usd, CurrencyOptions{Accounting: true}) -> ($1,234.00)
+// e.g. MoneyWithOptions(-1234, usd, CurrencyOptions{Accounting: true, NoSymbol: true}) -> (1,234.00)
+// e.g. MoneyWithOptions(1234, usd, CurrencyOptions{Code: true}) -> USD 1,234.00
+func MoneyWithOptions(amount float64, c Currency, opts CurrencyOptions) string {
+	if math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return strconv.FormatFloat(amount, 'f', -1, 64)
+	}
+	return formatMoney(strconv.FormatFloat(amount, 'f', -1, 64), c, opts)
+}
+
+// BigMoney works like Money for big floats, so that amounts such as
+// 1e20 keep all their digits.
+func BigMoney(amount *big.Float, code string) string {
+	return BigMoneyWithOptions(amount, moneyCurrency(code), CurrencyOptions{})
+}
+
+// BigMoneyWithOptions works like MoneyWithOptions for big floats.
+func BigMoneyWithOptions(amount *big.Float, c Currency, opts CurrencyOptions) string {
+	if amount.IsInf() {
+		return amount.Text('f', -1)
+	}
+	return formatMoney(amount.Text('f', -1), c, opts)
+}
+
+// RatMoney works like Money for exact rational amounts.
+//
+// e.g. RatMoney(big.NewRat(1, 3), "USD") -> $0.33
+func RatMoney(amount *big.Rat, code string) string {
+	return RatMoneyWithOptions(amount, moneyCurrency(code), CurrencyOptions{})
+}
+
+// RatMoneyWithOptions works like MoneyWithOptions for exact rational
+// amounts.
+func RatMoneyWithOptions(amount *big.Rat, c Currency, opts CurrencyOptions) string {
+	return formatMoney(ratDecimal(amount, c.MinorUnits), c, opts)
+}
+
+// ratDecimal returns r as a plain decimal number with enough digits
+// past the given number of decimal places for roundDecimal to round
+// it as if it had them all: one more digit, and a trailing 1 if any
+// digits were cut off.
+func ratDecimal(r *big.Rat, digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits+1)), nil)
+	num := new(big.Int).Mul(new(big.Int).Abs(r.Num()), scale)
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+
+	s := q.String()
+	if rem.Sign() != 0 {
+		s += "1"
+		digits++
+	}
+	if len(s) <= digits+1 {
+		s = strings.Repeat("0", digits+2-len(s)) + s
+	}
+	point := len(s) - digits - 1
+	s = s[:point] + "." + s[point:]
+	if r.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package humanize
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMoney(t *testing.T) {
+	testList{
+		{"USD", Money(1234.5, "USD"), "$1,234.50"},
+		{"usd", Money(0, "usd"), "$0.00"},
+		{"negative", Money(-1234, "USD"), "-$1,234.00"},
+		{"negative zero", Money(-0.001, "USD"), "$0.00"},
+		{"half even", Money(0.125, "USD"), "$0.12"},
+		{"JPY", Money(1234.5, "JPY"), "¥1,234"},
+		{"KWD", Money(-1.2345, "KWD"), "-KD 1.234"},
+		{"CHF", Money(10, "CHF"), "CHF 10.00"},
+		{"SEK", Money(1234.5, "SEK"), "1,234.50 kr"},
+		{"RUB", Money(99.999, "RUB"), "100.00 ₽"},
+		{"EUR", Money(1e6, "EUR"), "€1,000,000.00"},
+		{"unknown", Money(5, "XYZ"), "5.00 XYZ"},
+		{"inf", Money(math.Inf(1), "USD"), "+Inf"},
+	}.validate(t)
+}
+
+func TestMoneyWithOptions(t *testing.T) {
+	usd, err := LookupCurrency("USD")
+	if err != nil {
+		t.Fatalf("Error looking up USD: %v", err)
+	}
+	eur, _ := LookupCurrency("EUR")
+	testList{
+		{"accounting", MoneyWithOptions(-1234, usd, CurrencyOptions{Accounting: true}), "($1,234.00)"},
+		{"accounting positive", MoneyWithOptions(1234, usd, CurrencyOptions{Accounting: true}), "$1,234.00"},
+		{"accounting bare", MoneyWithOptions(-1234, usd, CurrencyOptions{Accounting: true, NoSymbol: true}), "(1,234.00)"},
+		{"code", MoneyWithOptions(1234, usd, CurrencyOptions{Code: true}), "USD 1,234.00"},
+		{"half up", MoneyWithOptions(0.125, usd, CurrencyOptions{Rounding: RoundHalfUp}), "$0.13"},
+		{"toward zero", MoneyWithOptions(-0.129, usd, CurrencyOptions{Rounding: RoundTowardZero}), "-$0.12"},
+		{"separators", MoneyWithOptions(1234567.891, Currency{"EUR", "€", 2, true}, CurrencyOptions{Decimal: ",", Group: "."}), "1.234.567,89 €"},
+		{"custom", MoneyWithOptions(3.5, Currency{Code: "BTC", Symbol: "₿", MinorUnits: 8}, CurrencyOptions{}), "₿3.50000000"},
+		{"big", BigMoneyWithOptions(big.NewFloat(-2.5), eur, CurrencyOptions{Accounting: true}), "(€2.50)"},
+	}.validate(t)
+
+	if _, err := LookupCurrency("XYZ"); err == nil {
+		t.Errorf("Expected error looking up XYZ")
+	}
+}
+
+func TestBigMoney(t *testing.T) {
+	exact, _, _ := big.ParseFloat("12345678901234567.565", 10, 200, big.ToNearestEven)
+	testList{
+		{"big", BigMoney(exact, "USD"), "$12,345,678,901,234,567.56"},
+		{"big small", BigMoney(big.NewFloat(0.5), "JPY"), "¥0"},
+		{"big inf", BigMoney(new(big.Float).SetInf(true), "USD"), "-Inf"},
+	}.validate(t)
+}
+
+func TestRatMoney(t *testing.T) {
+	testList{
+		{"third", RatMoney(big.NewRat(1, 3), "USD"), "$0.33"},
+		{"two thirds", RatMoney(big.NewRat(2, 3), "USD"), "$0.67"},
+		{"tie even", RatMoney(big.NewRat(1, 8), "USD"), "$0.12"},
+		{"tie odd", RatMoney(big.NewRat(3, 8), "USD"), "$0.38"},
+		{"just over tie", RatMoney(big.NewRat(125001, 1000000), "USD"), "$0.13"},
+		{"negative", RatMoney(big.NewRat(-1234567, 100), "USD"), "-$12,345.67"},
+		{"KWD", RatMoney(big.NewRat(22, 7), "KWD"), "KD 3.143"},
+		{"JPY", RatMoney(big.NewRat(5, 2), "JPY"), "¥2"},
+		{"small", RatMoney(big.NewRat(1, 1000), "USD"), "$0.00"},
+	}.validate(t)
+
+	usd, _ := LookupCurrency("USD")
+	if got, exp := RatMoneyWithOptions(big.NewRat(-1, 200), usd, CurrencyOptions{Accounting: true, Rounding: RoundHalfUp}), "($0.01)"; got != exp {
+		t.Errorf("Expected %v, got %v", exp, got)
+	}
+}
@@ -0,0 +1,222 @@
+package english
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var smallNumbers = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+	"seventeen", "eighteen", "nineteen",
+}
+
+var tens = []string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+}
+
+// scaleNames are the short scale names of the powers of a thousand,
+// starting with 10^3.
+var scaleNames = []string{
+	"thousand", "million", "billion", "trillion", "quadrillion",
+	"quintillion", "sextillion", "septillion", "octillion", "nonillion",
+	"decillion", "undecillion", "duodecillion", "tredecillion",
+	"quattuordecillion", "quindecillion", "sexdecillion",
+	"septendecillion", "octodecillion", "novemdecillion", "vigintillion",
+}
+
+// scaleName returns the name of 1000^k.  Past the last name in
+// scaleNames the names are compounded, as in "thousand vigintillion".
+func scaleName(k int) string {
+	var names []string
+	for ; k > len(scaleNames); k -= len(scaleNames) {
+		names = append(names, scaleNames[len(scaleNames)-1])
+	}
+	if k > 0 {
+		names = append([]string{scaleNames[k-1]}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+// hundreds writes a number below a thousand.
+func hundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, smallNumbers[n/100], "hundred")
+		n %= 100
+		if n == 0 {
+			return strings.Join(words, " ")
+		}
+	}
+	switch {
+	case n < 20:
+		words = append(words, smallNumbers[n])
+	case n%10 == 0:
+		words = append(words, tens[n/10])
+	default:
+		words = append(words, tens[n/10]+"-"+smallNumbers[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// cardinal writes the decimal digits of a whole number, with an
+// optional leading minus sign, in words.
+func cardinal(digits string) string {
+	prefix := ""
+	if strings.HasPrefix(digits, "-") {
+		prefix, digits = "minus ", digits[1:]
+	}
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "zero"
+	}
+
+	var words []string
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	for start, end := 0, first; start < len(digits); start, end = end, end+3 {
+		n, _ := strconv.Atoi(digits[start:end])
+		if n == 0 {
+			continue
+		}
+		k := (len(digits) - end) / 3
+		words = append(words, hundreds(n))
+		if k > 0 {
+			words = append(words, scaleName(k))
+		}
+	}
+	return prefix + strings.Join(words, " ")
+}
+
+// Cardinal writes a whole number in English words.
+//
+// e.g. Cardinal(42) -> forty-two
+// e.g. Cardinal(1234) -> one thousand two hundred thirty-four
+// e.g. Cardinal(-7) -> minus seven
+func Cardinal(n int64) string {
+	return cardinal(strconv.FormatInt(n, 10))
+}
+
+// BigCardinal works like Cardinal for big integers.
+func BigCardinal(n *big.Int) string {
+	return cardinal(n.String())
+}
+
+// Cardinalf writes a number in English words, reading the digits
+// after the decimal point one by one.
+//
+// e.g. Cardinalf(3.14) -> three point one four
+// e.g. Cardinalf(-0.5) -> minus zero point five
+func Cardinalf(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "not a number"
+	case math.IsInf(f, 1):
+		return "infinity"
+	case math.IsInf(f, -1):
+		return "minus infinity"
+	}
+	prefix := ""
+	if f < 0 {
+		prefix = "minus "
+	}
+	s := strconv.FormatFloat(math.Abs(f), 'f', -1, 64)
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return prefix + cardinal(s)
+	}
+	words := []string{cardinal(s[:i]), "point"}
+	for _, d := range s[i+1:] {
+		words = append(words, smallNumbers[d-'0'])
+	}
+	return prefix + strings.Join(words, " ")
+}
+
+// CurrencyWords writes an amount of money as on a cheque: the whole
+// units in words and the minor units as a fraction of the number of
+// minor units in one, rounded to the given number of digits.
+//
+// e.g. CurrencyWords(1234.45, 2) -> one thousand two hundred thirty-four and 45/100
+// e.g. CurrencyWords(20, 2) -> twenty and 00/100
+func CurrencyWords(amount float64, minorDigits int) string {
+	if minorDigits < 0 {
+		minorDigits = 0
+	}
+	if math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return Cardinalf(amount)
+	}
+	s := strconv.FormatFloat(amount, 'f', minorDigits, 64)
+	prefix := ""
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
+		if strings.Trim(s, "0.") != "" {
+			prefix = "minus "
+		}
+	}
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return prefix + cardinal(s)
+	}
+	return prefix + cardinal(s[:i]) + " and " + s[i+1:] + "/1" + strings.Repeat("0", minorDigits)
+}
+
+// irregularOrdinals are the ordinals not made by adding "th".
+var irregularOrdinals = map[string]string{
+	"one":    "first",
+	"two":    "second",
+	"three":  "third",
+	"five":   "fifth",
+	"eight":  "eighth",
+	"nine":   "ninth",
+	"twelve": "twelfth",
+}
+
+// ordinal turns the cardinal words of a number into ordinal words by
+// changing the last one.
+func ordinal(words string) string {
+	i := strings.LastIndexAny(words, " -") + 1
+	last := words[i:]
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = last[:len(last)-1] + "ieth"
+	default:
+		last += "th"
+	}
+	return words[:i] + last
+}
+
+// Ordinal writes a whole number as English ordinal words.
+//
+// See also: humanize.Ordinal, which writes "22nd".
+//
+// e.g. Ordinal(1) -> first
+// e.g. Ordinal(22) -> twenty-second
+// e.g. Ordinal(1000000) -> one millionth
+func Ordinal(n int64) string {
+	return ordinal(Cardinal(n))
+}
+
+// BigOrdinal works like Ordinal for big integers.
+func BigOrdinal(n *big.Int) string {
+	return ordinal(BigCardinal(n))
+}
+
+// Capitalize returns the words with the first letter in upper case,
+// for number words that start a sentence.
+//
+// e.g. Capitalize(Ordinal(1)) -> First
+// e.g. Capitalize(Cardinal(42)) -> Forty-two
+func Capitalize(words string) string {
+	for i, r := range words {
+		return string(unicode.ToUpper(r)) + words[i+utf8.RuneLen(r):]
+	}
+	return words
+}
@@ -0,0 +1,150 @@
+package english
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestCardinal(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "zero"},
+		{7, "seven"},
+		{13, "thirteen"},
+		{20, "twenty"},
+		{42, "forty-two"},
+		{100, "one hundred"},
+		{101, "one hundred one"},
+		{999, "nine hundred ninety-nine"},
+		{1000, "one thousand"},
+		{1234, "one thousand two hundred thirty-four"},
+		{1000001, "one million one"},
+		{2000300, "two million three hundred"},
+		{-7, "minus seven"},
+		{math.MaxInt64, "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+		{math.MinInt64, "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+	}
+	for _, tt := range tests {
+		if got := Cardinal(tt.n); got != tt.want {
+			t.Errorf("Cardinal(%d)=%q; want: %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestBigCardinal(t *testing.T) {
+	tests := []struct {
+		n    string
+		want string
+	}{
+		{"42", "forty-two"},
+		{"-1000000000000", "minus one trillion"},
+		{"12000000000000000000000000000000000", "twelve decillion"},
+		{"1" + strings.Repeat("0", 63), "one vigintillion"},
+		{"1" + strings.Repeat("0", 66), "one thousand vigintillion"},
+		{"2" + strings.Repeat("0", 126), "two vigintillion vigintillion"},
+	}
+	for _, tt := range tests {
+		n, _ := new(big.Int).SetString(tt.n, 10)
+		if got := BigCardinal(n); got != tt.want {
+			t.Errorf("BigCardinal(%s)=%q; want: %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCardinalf(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{3.14, "three point one four"},
+		{-0.5, "minus zero point five"},
+		{42, "forty-two"},
+		{1000.05, "one thousand point zero five"},
+		{math.Copysign(0, -1), "zero"},
+		{math.Inf(1), "infinity"},
+		{math.NaN(), "not a number"},
+	}
+	for _, tt := range tests {
+		if got := Cardinalf(tt.f); got != tt.want {
+			t.Errorf("Cardinalf(%v)=%q; want: %q", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyWords(t *testing.T) {
+	tests := []struct {
+		amount float64
+		digits int
+		want   string
+	}{
+		{1234.45, 2, "one thousand two hundred thirty-four and 45/100"},
+		{20, 2, "twenty and 00/100"},
+		{0.5, 2, "zero and 50/100"},
+		{99.999, 2, "one hundred and 00/100"},
+		{-12.3, 2, "minus twelve and 30/100"},
+		{-0.001, 2, "zero and 00/100"},
+		{7.125, 3, "seven and 125/1000"},
+		{1500, 0, "one thousand five hundred"},
+	}
+	for _, tt := range tests {
+		if got := CurrencyWords(tt.amount, tt.digits); got != tt.want {
+			t.Errorf("CurrencyWords(%v, %d)=%q; want: %q", tt.amount, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "zeroth"},
+		{1, "first"},
+		{2, "second"},
+		{3, "third"},
+		{4, "fourth"},
+		{5, "fifth"},
+		{8, "eighth"},
+		{9, "ninth"},
+		{11, "eleventh"},
+		{12, "twelfth"},
+		{20, "twentieth"},
+		{22, "twenty-second"},
+		{99, "ninety-ninth"},
+		{100, "one hundredth"},
+		{101, "one hundred first"},
+		{1000000, "one millionth"},
+		{1234, "one thousand two hundred thirty-fourth"},
+		{-1, "minus first"},
+	}
+	for _, tt := range tests {
+		if got := Ordinal(tt.n); got != tt.want {
+			t.Errorf("Ordinal(%d)=%q; want: %q", tt.n, got, tt.want)
+		}
+	}
+
+	n, _ := new(big.Int).SetString("1"+strings.Repeat("0", 31)+"12", 10)
+	if got, want := BigOrdinal(n), "one decillion twelfth"; got != want {
+		t.Errorf("BigOrdinal(%v)=%q; want: %q", n, got, want)
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct {
+		words, want string
+	}{
+		{Ordinal(1), "First"},
+		{Cardinal(42), "Forty-two"},
+		{"", ""},
+		{"épée", "Épée"},
+	}
+	for _, tt := range tests {
+		if got := Capitalize(tt.words); got != tt.want {
+			t.Errorf("Capitalize(%q)=%q; want: %q", tt.words, got, tt.want)
+		}
+	}
+}
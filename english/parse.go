@@ -0,0 +1,328 @@
+package english
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// ErrAmbiguous is returned, wrapped, when number words could be read
+// in more than one way, such as "nineteen eighty-four" or "one two".
+var ErrAmbiguous = errors.New("ambiguous number words")
+
+// wordKind is the kind of a number word, which decides which words
+// may follow it.
+type wordKind int
+
+const (
+	noWord wordKind = iota
+	unitWord
+	teenWord
+	tensWord
+	hundredWord
+	scaleWord
+	digitsWord
+	articleWord
+	fractionWord
+	pointWord
+)
+
+// numberWord is a word's kind and value.  The value of "hundred" and
+// the scale names is their power of ten.
+type numberWord struct {
+	kind  wordKind
+	value int
+}
+
+var numberWords = map[string]numberWord{}
+
+// ordinalWords maps ordinal words such as "twentieth" to their
+// cardinals.
+var ordinalWords = map[string]string{}
+
+func init() {
+	for i, w := range smallNumbers {
+		kind := unitWord
+		if i >= 10 {
+			kind = teenWord
+		}
+		numberWords[w] = numberWord{kind, i}
+		ordinalWords[ordinal(w)] = w
+	}
+	for i, w := range tens {
+		if w != "" {
+			numberWords[w] = numberWord{tensWord, i * 10}
+			ordinalWords[ordinal(w)] = w
+		}
+	}
+	numberWords["hundred"] = numberWord{hundredWord, 2}
+	ordinalWords[ordinal("hundred")] = "hundred"
+	for i, w := range scaleNames {
+		numberWords[w] = numberWord{scaleWord, 3 * (i + 1)}
+		ordinalWords[ordinal(w)] = w
+	}
+}
+
+var fractionWords = map[string]*big.Rat{
+	"half":     big.NewRat(1, 2),
+	"halves":   big.NewRat(1, 2),
+	"quarter":  big.NewRat(1, 4),
+	"quarters": big.NewRat(1, 4),
+}
+
+var wordDigitsRegex = regexp.MustCompile(`^(?:[0-9][0-9,]*(?:\.[0-9]+)?|\.[0-9]+)$`)
+
+// wordTokens splits number words into lower case tokens, splitting
+// hyphenated words and dropping the commas that separate groups.  A
+// standalone "-" is kept as a token of its own.
+func wordTokens(s string) []string {
+	var tokens []string
+	for _, f := range strings.Fields(strings.ToLower(s)) {
+		f = strings.TrimSuffix(f, ",")
+		if f == "-" || wordDigitsRegex.MatchString(f) || len(tokens) == 0 && wordDigitsRegex.MatchString(strings.TrimPrefix(f, "-")) {
+			tokens = append(tokens, f)
+			continue
+		}
+		for _, w := range strings.Split(f, "-") {
+			if w != "" {
+				tokens = append(tokens, w)
+			}
+		}
+	}
+	return tokens
+}
+
+// fractionAfterAnd reads the fraction in "and a half" or "and three
+// quarters" from the words following "and", returning its value and
+// the number of words it used.
+func fractionAfterAnd(words []string) (*big.Rat, int, bool) {
+	if len(words) < 2 {
+		return nil, 0, false
+	}
+	f, ok := fractionWords[words[1]]
+	if !ok {
+		return nil, 0, false
+	}
+	n := int64(1)
+	if w := words[0]; w != "a" && w != "an" {
+		u, ok := numberWords[w]
+		if !ok || u.kind != unitWord || u.value == 0 {
+			return nil, 0, false
+		}
+		n = int64(u.value)
+	}
+	return new(big.Rat).Mul(f, big.NewRat(n, 1)), 2, true
+}
+
+// largestScale is the name compounded to write scales beyond it.
+var largestScale = scaleNames[len(scaleNames)-1]
+
+// scaleToken returns the cardinal of w if it is the last word and an
+// ordinal, or w otherwise.
+func scaleToken(w string, last bool) string {
+	if c, ok := ordinalWords[w]; ok && last {
+		return c
+	}
+	return w
+}
+
+func ambiguous(w, prev string) error {
+	return fmt.Errorf("%w: %q after %q", ErrAmbiguous, w, prev)
+}
+
+// parseWords reads number words into an exact value.
+func parseWords(s string) (*big.Rat, error) {
+	tokens := wordTokens(s)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no number words in %q", s)
+	}
+	neg := false
+	switch {
+	case tokens[0] == "minus" || tokens[0] == "negative" || tokens[0] == "-":
+		neg, tokens = true, tokens[1:]
+	case strings.HasPrefix(tokens[0], "-"):
+		neg, tokens[0] = true, tokens[0][1:]
+	}
+
+	total, group := new(big.Rat), new(big.Rat)
+	prev := noWord
+	lastScale := -1
+	for i := 0; i < len(tokens); i++ {
+		w := tokens[i]
+		prevWord := ""
+		if i > 0 {
+			prevWord = tokens[i-1]
+		}
+		if c, ok := ordinalWords[w]; ok {
+			if i != len(tokens)-1 {
+				return nil, fmt.Errorf("ordinal %q must be the last word of %q", w, s)
+			}
+			w = c
+		}
+
+		switch w {
+		case "a", "an":
+			if prev != noWord && prev != scaleWord {
+				return nil, fmt.Errorf("unexpected %q after %q", w, prevWord)
+			}
+			group.SetInt64(1)
+			prev = articleWord
+			continue
+		case "and":
+			if f, n, ok := fractionAfterAnd(tokens[i+1:]); ok {
+				if prev != unitWord && prev != teenWord && prev != tensWord && prev != hundredWord && prev != digitsWord {
+					return nil, fmt.Errorf("unexpected fraction after %q", prevWord)
+				}
+				group.Add(group, f)
+				i += n
+				prev = fractionWord
+				continue
+			}
+			if (prev != hundredWord && prev != scaleWord) || i == len(tokens)-1 {
+				return nil, fmt.Errorf("unexpected %q in %q", w, s)
+			}
+			continue
+		case "point":
+			if prev != unitWord && prev != teenWord && prev != tensWord && prev != hundredWord && prev != scaleWord {
+				return nil, fmt.Errorf("unexpected %q after %q", w, prevWord)
+			}
+			digits := ""
+			for ; i+1 < len(tokens); i++ {
+				u, ok := numberWords[tokens[i+1]]
+				if !ok || u.kind != unitWord {
+					break
+				}
+				digits += string(rune('0' + u.value))
+			}
+			if digits == "" {
+				return nil, fmt.Errorf("missing digits after %q", w)
+			}
+			f, _ := new(big.Rat).SetString("0." + digits)
+			group.Add(group, f)
+			prev = pointWord
+			continue
+		}
+
+		if wordDigitsRegex.MatchString(w) {
+			if prev != noWord && prev != scaleWord {
+				return nil, ambiguous(w, prevWord)
+			}
+			v, ok := new(big.Rat).SetString(strings.Replace(w, ",", "", -1))
+			if !ok {
+				return nil, fmt.Errorf("invalid number %q", w)
+			}
+			group.Set(v)
+			prev = digitsWord
+			continue
+		}
+
+		word, ok := numberWords[w]
+		if !ok {
+			return nil, fmt.Errorf("unknown number word %q", w)
+		}
+		switch word.kind {
+		case unitWord:
+			if word.value == 0 && len(tokens) > 1 && (i != 0 || tokens[1] != "point") {
+				return nil, fmt.Errorf("unexpected %q in %q", w, s)
+			}
+			if prev != noWord && prev != hundredWord && prev != scaleWord && prev != tensWord {
+				return nil, ambiguous(w, prevWord)
+			}
+			group.Add(group, big.NewRat(int64(word.value), 1))
+		case teenWord, tensWord:
+			if prev != noWord && prev != hundredWord && prev != scaleWord {
+				return nil, ambiguous(w, prevWord)
+			}
+			group.Add(group, big.NewRat(int64(word.value), 1))
+		case hundredWord:
+			if prev != unitWord && prev != teenWord && prev != tensWord && prev != digitsWord && prev != articleWord {
+				return nil, fmt.Errorf("unexpected %q after %q", w, prevWord)
+			}
+			if group.Cmp(big.NewRat(100, 1)) >= 0 {
+				return nil, ambiguous(w, prevWord)
+			}
+			group.Mul(group, big.NewRat(100, 1))
+		case scaleWord:
+			if prev == noWord || prev == scaleWord || group.Sign() == 0 {
+				return nil, fmt.Errorf("missing number before %q", w)
+			}
+			// Past the largest name, scale names are compounded with
+			// it, as in "thousand vigintillion".
+			exp := word.value
+			for i+1 < len(tokens) && scaleToken(tokens[i+1], i+1 == len(tokens)-1) == largestScale {
+				exp += numberWords[largestScale].value
+				i++
+			}
+			if lastScale >= 0 && exp >= lastScale {
+				return nil, ambiguous(tokens[i], prevWord)
+			}
+			scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+			total.Add(total, group.Mul(group, new(big.Rat).SetInt(scale)))
+			group = new(big.Rat)
+			lastScale = exp
+		}
+		prev = word.kind
+	}
+
+	if prev == noWord || prev == articleWord {
+		return nil, fmt.Errorf("incomplete number words %q", s)
+	}
+	total.Add(total, group)
+	if neg {
+		total.Neg(total)
+	}
+	return total, nil
+}
+
+// ParseBigCardinal reads a number written in English words, as
+// Cardinal and Ordinal write them, into a big integer.
+//
+// Digits may stand in for words ("2 million"), "and" may follow
+// "hundred" or a scale name ("one hundred and five"), and fractions
+// of a half or a quarter may come before a scale name ("three and a
+// half million").  Scale names past vigintillion are compounded as
+// BigCardinal writes them ("one thousand vigintillion").  Words that
+// run together in a way that could mean more than one number, such as
+// "nineteen eighty-four", are an error wrapping ErrAmbiguous.  Numbers
+// that are not whole are an error.
+//
+// e.g. ParseBigCardinal("twelve hundred") -> 1200, nil
+func ParseBigCardinal(s string) (*big.Int, error) {
+	r, err := parseWords(s)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%q is not a whole number", s)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// ParseCardinal works like ParseBigCardinal, but returns an int64.
+//
+// e.g. ParseCardinal("three and a half million") -> 3500000, nil
+// e.g. ParseCardinal("twenty-second") -> 22, nil
+func ParseCardinal(s string) (int64, error) {
+	n, err := ParseBigCardinal(s)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%q is out of range", s)
+	}
+	return n.Int64(), nil
+}
+
+// ParseCardinalf works like ParseBigCardinal, but returns a float64
+// and accepts numbers that are not whole, such as "three point one
+// four" or "two and a half".
+func ParseCardinalf(s string) (float64, error) {
+	r, err := parseWords(s)
+	if err != nil {
+		return 0, err
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
@@ -0,0 +1,166 @@
+package english
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseCardinal(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int64
+	}{
+		{"zero", 0},
+		{"forty-two", 42},
+		{"Forty Two", 42},
+		{"twelve hundred", 1200},
+		{"a hundred", 100},
+		{"one hundred and five", 105},
+		{"one thousand two hundred thirty-four", 1234},
+		{"one thousand, two hundred and thirty-four", 1234},
+		{"three and a half million", 3500000},
+		{"two and three quarters thousand", 2750},
+		{"a million", 1000000},
+		{"2 million", 2000000},
+		{"1.5 billion", 1500000000},
+		{"1,200 thousand", 1200000},
+		{"2 million 500 thousand", 2500000},
+		{"twelve hundred thousand", 1200000},
+		{"two point five million", 2500000},
+		{"minus seven", -7},
+		{"-3 thousand", -3000},
+		{"- 5", -5},
+		{"- seven", -7},
+		{"first", 1},
+		{"twenty-second", 22},
+		{"one hundredth", 100},
+		{"one thousand two hundred thirty-fourth", 1234},
+		{"one millionth", 1000000},
+		{Cardinal(math.MaxInt64), math.MaxInt64},
+		{Cardinal(math.MinInt64), math.MinInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseCardinal(tt.s)
+		if err != nil {
+			t.Errorf("ParseCardinal(%q) error: %v", tt.s, err)
+		} else if got != tt.want {
+			t.Errorf("ParseCardinal(%q)=%d; want: %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseCardinalErrors(t *testing.T) {
+	tests := []struct {
+		s         string
+		ambiguous bool
+	}{
+		{"", false},
+		{"apple", false},
+		{"thousand", false},
+		{"a", false},
+		{"one and", false},
+		{"two and a half", false}, // not whole
+		{"nine quintillion three hundred quadrillion", false},
+		{"first two", false},
+		{"zero five", false},
+		{"one two", true},
+		{"nineteen eighty-four", true},
+		{"twenty thirty", true},
+		{"one thousand one million", true},
+		{"one thousand thousand", false},
+		{"one hundred one hundred", true},
+		{"2 five", true},
+		{"-", false},
+		{"five -", false},
+		{"- - 5", false},
+	}
+	for _, tt := range tests {
+		got, err := ParseCardinal(tt.s)
+		if err == nil {
+			t.Errorf("ParseCardinal(%q)=%d; want error", tt.s, got)
+			continue
+		}
+		if errors.Is(err, ErrAmbiguous) != tt.ambiguous {
+			t.Errorf("ParseCardinal(%q) error %v; ambiguous want: %v", tt.s, err, tt.ambiguous)
+		}
+	}
+}
+
+func TestParseBigCardinal(t *testing.T) {
+	want, _ := new(big.Int).SetString("12"+strings.Repeat("0", 33), 10)
+	for _, s := range []string{"twelve decillion", BigCardinal(want), "12 decillion"} {
+		got, err := ParseBigCardinal(s)
+		if err != nil {
+			t.Errorf("ParseBigCardinal(%q) error: %v", s, err)
+		} else if got.Cmp(want) != 0 {
+			t.Errorf("ParseBigCardinal(%q)=%v; want: %v", s, got, want)
+		}
+	}
+}
+
+func TestParseBigCardinalCompound(t *testing.T) {
+	for _, zeros := range []int{63, 66, 123, 126, 129} {
+		for _, lead := range []string{"1", "2", "999"} {
+			want, _ := new(big.Int).SetString(lead+strings.Repeat("0", zeros), 10)
+			want.Add(want, big.NewInt(7))
+			s := BigCardinal(want)
+			got, err := ParseBigCardinal(s)
+			if err != nil {
+				t.Errorf("ParseBigCardinal(%q) error: %v", s, err)
+			} else if got.Cmp(want) != 0 {
+				t.Errorf("ParseBigCardinal(%q)=%v; want: %v", s, got, want)
+			}
+		}
+	}
+
+	want, _ := new(big.Int).SetString("1"+strings.Repeat("0", 66), 10)
+	if got, err := ParseBigCardinal("one thousand vigintillionth"); err != nil || got.Cmp(want) != 0 {
+		t.Errorf("ParseBigCardinal(ordinal) = %v, %v; want: %v", got, err, want)
+	}
+
+	for _, s := range []string{"one vigintillion one thousand vigintillion", "one thousand vigintillion two vigintillion vigintillion"} {
+		if got, err := ParseBigCardinal(s); !errors.Is(err, ErrAmbiguous) {
+			t.Errorf("ParseBigCardinal(%q) = %v, %v; want ErrAmbiguous", s, got, err)
+		}
+	}
+}
+
+func TestParseCardinalf(t *testing.T) {
+	tests := []struct {
+		s    string
+		want float64
+	}{
+		{"three point one four", 3.14},
+		{"zero point five", 0.5},
+		{"two and a half", 2.5},
+		{"minus zero point two five", -0.25},
+		{"- 5", -5},
+		{"1.25", 1.25},
+		{Cardinalf(1000.05), 1000.05},
+	}
+	for _, tt := range tests {
+		got, err := ParseCardinalf(tt.s)
+		if err != nil {
+			t.Errorf("ParseCardinalf(%q) error: %v", tt.s, err)
+		} else if got != tt.want {
+			t.Errorf("ParseCardinalf(%q)=%v; want: %v", tt.s, got, tt.want)
+		}
+	}
+	if got, err := ParseCardinalf("three point"); err == nil {
+		t.Errorf("ParseCardinalf(%q)=%v; want error", "three point", got)
+	}
+}
+
+func TestParseCardinalRoundTrip(t *testing.T) {
+	for _, n := range []int64{0, 1, 11, 99, 100, 101, 110, 999, 1001, 20020, 7000000, 123456789} {
+		for _, s := range []string{Cardinal(n), Ordinal(n)} {
+			got, err := ParseCardinal(s)
+			if err != nil || got != n {
+				t.Errorf("ParseCardinal(%q)=%d, %v; want: %d", s, got, err, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package humanize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter adapts one of the humanize functions to fmt.Formatter, so
+// values can be humanized directly in Printf verbs:
+//
+//	fmt.Printf("%-10h %8h\n", humanize.Size(n), humanize.Number(count))
+//
+// The %h, %v and %s verbs produce the humanized string, padded to the
+// width of the directive and left aligned with the '-' flag.  The
+// precision, where the underlying function supports one, selects its
+// number of digits (e.g. "%.3h" of a Size uses BytesN(n, 3)).  %q
+// quotes the humanized string, and other verbs such as %d or %f print
+// the raw value.
+//
+// Use Size, ISize, Number, Numberf, SIValue or TimeValue to build a
+// Formatter.  The zero Formatter prints as "<nil>".
+type Formatter struct {
+	// human renders the value, with precision -1 when none is given.
+	human func(precision int) string
+	raw   interface{}
+}
+
+// Size formats n as Bytes, or BytesN with a precision.  The %H verb
+// formats it as IBytes instead.
+func Size(n uint64) Formatter {
+	return Formatter{func(p int) string {
+		if p < 0 {
+			return Bytes(n)
+		}
+		return BytesN(n, p)
+	}, n}
+}
+
+// ISize formats n as IBytes, or IBytesN with a precision.
+func ISize(n uint64) Formatter {
+	return Formatter{func(p int) string {
+		if p < 0 {
+			return IBytes(n)
+		}
+		return IBytesN(n, p)
+	}, n}
+}
+
+// Number formats n as Comma.
+func Number(n int64) Formatter {
+	return Formatter{func(int) string {
+		return Comma(n)
+	}, n}
+}
+
+// Numberf formats f as Commaf, or CommafWithDigits with a precision.
+func Numberf(f float64) Formatter {
+	return Formatter{func(p int) string {
+		if p < 0 {
+			return Commaf(f)
+		}
+		return CommafWithDigits(f, p)
+	}, f}
+}
+
+// SIValue formats input as SI, or SIWithDigits with a precision.
+func SIValue(input float64, unit string) Formatter {
+	return Formatter{func(p int) string {
+		if p < 0 {
+			return SI(input, unit)
+		}
+		return SIWithDigits(input, p, unit)
+	}, input}
+}
+
+// TimeValue formats then as Time.
+func TimeValue(then time.Time) Formatter {
+	return Formatter{func(int) string {
+		return Time(then)
+	}, then}
+}
+
+// text returns the humanized value, or the raw one for a Formatter
+// not built by one of the functions above.
+func (h Formatter) text(precision int) string {
+	if h.human == nil {
+		return fmt.Sprint(h.raw)
+	}
+	return h.human(precision)
+}
+
+// String returns the humanized value.
+func (h Formatter) String() string {
+	return h.text(-1)
+}
+
+// Format implements fmt.Formatter.
+func (h Formatter) Format(f fmt.State, verb rune) {
+	precision := -1
+	if p, ok := f.Precision(); ok {
+		precision = p
+	}
+	switch verb {
+	case 'h', 'v', 's':
+		padFormat(f, h.text(precision))
+	case 'q':
+		padFormat(f, strconv.Quote(h.text(precision)))
+	case 'H':
+		if n, ok := h.raw.(uint64); ok {
+			ISize(n).Format(f, 'h')
+			return
+		}
+		padFormat(f, h.text(precision))
+	default:
+		fmt.Fprintf(f, formatDirective(f, verb), h.raw)
+	}
+}
+
+// formatDirective rebuilds the formatting directive (e.g. "%-10.2s")
+// that produced f, for the given verb.
+func formatDirective(f fmt.State, verb rune) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			sb.WriteRune(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		sb.WriteString(strconv.Itoa(w))
+	}
+	if p, ok := f.Precision(); ok {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(p))
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
+
+// padFormat writes s to f honoring the width and '-' flag of the
+// directive, but not its precision.
+func padFormat(f fmt.State, s string) {
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	fmt.Fprintf(f, format+"s", s)
+}
@@ -0,0 +1,50 @@
+package humanize
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFormatter(t *testing.T) {
+	testList{
+		{"size %h", fmt.Sprintf("%h", Size(82854982)), "83 MB"},
+		{"size %v", fmt.Sprintf("%v", Size(82854982)), "83 MB"},
+		{"size %s", fmt.Sprintf("%s", Size(82854982)), "83 MB"},
+		{"size %.4h", fmt.Sprintf("%.4h", Size(82854982)), "82.86 MB"},
+		{"size %H", fmt.Sprintf("%H", Size(82854982)), "79 MiB"},
+		{"size %.4H", fmt.Sprintf("%.4H", Size(82854982)), "79.02 MiB"},
+		{"size %d", fmt.Sprintf("%d", Size(82854982)), "82854982"},
+		{"size %q", fmt.Sprintf("%q", Size(1000)), `"1.0 kB"`},
+		{"size String", Size(1000).String(), "1.0 kB"},
+		{"isize %h", fmt.Sprintf("%h", ISize(82854982)), "79 MiB"},
+		{"number %h", fmt.Sprintf("%h", Number(834142)), "834,142"},
+		{"number %d", fmt.Sprintf("%d", Number(834142)), "834142"},
+		{"number %10h", fmt.Sprintf("%10h", Number(-834142)), "  -834,142"},
+		{"numberf %h", fmt.Sprintf("%h", Numberf(834142.32)), "834,142.32"},
+		{"numberf %.1h", fmt.Sprintf("%.1h", Numberf(834142.32)), "834,142.3"},
+		{"numberf %.3f", fmt.Sprintf("%.3f", Numberf(834142.32)), "834142.320"},
+		{"si %h", fmt.Sprintf("%h", SIValue(2.2345e-12, "F")), "2.2345 pF"},
+		{"si %.2h", fmt.Sprintf("%.2h", SIValue(2.2345e-12, "F")), "2.23 pF"},
+		{"si %-10h|", fmt.Sprintf("%-10h|", SIValue(1e6, "Hz")), "1 MHz     |"},
+		{"zero String", Formatter{}.String(), "<nil>"},
+		{"zero %8h", fmt.Sprintf("%8h", Formatter{}), "   <nil>"},
+		{"zero %q", fmt.Sprintf("%q", Formatter{}), `"<nil>"`},
+		{"time %h", fmt.Sprintf("%h", TimeValue(time.Now().Add(-3*time.Hour))), "3 hours ago"},
+	}.validate(t)
+}
+
+func TestFormatterTable(t *testing.T) {
+	sizes := []uint64{0, 1024, 82854982, 5 * GByte}
+	got := ""
+	for _, s := range sizes {
+		got += fmt.Sprintf("%-6d|%8h|\n", Size(s), Size(s))
+	}
+	exp := "0     |     0 B|\n" +
+		"1024  |  1.0 kB|\n" +
+		"82854982|   83 MB|\n" +
+		"5000000000|  5.0 GB|\n"
+	if got != exp {
+		t.Errorf("Expected\n%s\ngot\n%s", exp, got)
+	}
+}
@@ -0,0 +1,199 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// vulgarFractions are the Unicode glyphs for common fractions.
+var vulgarFractions = map[string]string{
+	"1/2": "½",
+	"1/3": "⅓", "2/3": "⅔",
+	"1/4": "¼", "3/4": "¾",
+	"1/5": "⅕", "2/5": "⅖", "3/5": "⅗", "4/5": "⅘",
+	"1/6": "⅙", "5/6": "⅚",
+	"1/7": "⅐",
+	"1/8": "⅛", "3/8": "⅜", "5/8": "⅝", "7/8": "⅞",
+	"1/9":  "⅑",
+	"1/10": "⅒",
+}
+
+var vulgarFractionsReverse *strings.Replacer
+
+func init() {
+	var pairs []string
+	for ascii, glyph := range vulgarFractions {
+		pairs = append(pairs, glyph, " "+ascii)
+	}
+	vulgarFractionsReverse = strings.NewReplacer(pairs...)
+}
+
+// FractionOptions controls how FractionWithOptions writes a number.
+type FractionOptions struct {
+	// MaxDenominator limits the denominator of the fraction the
+	// number is approximated by.  It is 16 when not positive.
+	MaxDenominator int64
+	// ASCII writes "1 1/2" rather than "1½".  Fractions without a
+	// Unicode glyph are always written this way.
+	ASCII bool
+}
+
+const defaultMaxDenominator = 16
+
+// limitDenominator returns the fraction closest to r whose
+// denominator is at most max.
+func limitDenominator(r *big.Rat, max int64) *big.Rat {
+	if r.Denom().Cmp(big.NewInt(max)) <= 0 {
+		return new(big.Rat).Set(r)
+	}
+	abs := new(big.Rat).Abs(r)
+	limit := big.NewInt(max)
+
+	// Walk the continued fraction of r until the next convergent's
+	// denominator is too large.
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, d := new(big.Int).Set(abs.Num()), new(big.Int).Set(abs.Denom())
+	a, t := new(big.Int), new(big.Int)
+	for {
+		a.Quo(n, d)
+		q2 := new(big.Int).Add(q0, t.Mul(a, q1))
+		if q2.Cmp(limit) > 0 {
+			break
+		}
+		p2 := new(big.Int).Add(p0, t.Mul(a, p1))
+		p0, q0, p1, q1 = p1, q1, p2, q2
+		n, d = d, new(big.Int).Sub(n, t.Mul(a, d))
+	}
+
+	// The best approximation is the last convergent, or the largest
+	// semiconvergent that fits.
+	k := new(big.Int).Quo(new(big.Int).Sub(limit, q0), q1)
+	semi := new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)))
+	conv := new(big.Rat).SetFrac(p1, q1)
+	rv := conv
+	if new(big.Rat).Abs(new(big.Rat).Sub(semi, abs)).Cmp(new(big.Rat).Abs(new(big.Rat).Sub(conv, abs))) < 0 {
+		rv = semi
+	}
+	if r.Sign() < 0 {
+		rv.Neg(rv)
+	}
+	return rv
+}
+
+// formatFraction writes r as a whole number, a fraction or a mixed
+// number.
+func formatFraction(r *big.Rat, opts FractionOptions) string {
+	max := opts.MaxDenominator
+	if max <= 0 {
+		max = defaultMaxDenominator
+	}
+	r = limitDenominator(r, max)
+
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+	}
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+	whole, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return sign + whole.String()
+	}
+
+	frac := rem.String() + "/" + den.String()
+	if glyph, ok := vulgarFractions[frac]; ok && !opts.ASCII {
+		if whole.Sign() == 0 {
+			return sign + glyph
+		}
+		return sign + whole.String() + glyph
+	}
+	if whole.Sign() == 0 {
+		return sign + frac
+	}
+	return sign + whole.String() + " " + frac
+}
+
+// Fraction writes a number as the nearest fraction or mixed number
+// with a denominator of at most 16, with a Unicode glyph when there is
+// one.
+//
+// See also: FractionWithOptions, ParseFraction.
+//
+// e.g. Fraction(1.5) -> 1½
+// e.g. Fraction(0.333) -> ⅓
+// e.g. Fraction(2.1875) -> 2 3/16
+func Fraction(f float64) string {
+	return FractionWithOptions(f, FractionOptions{})
+}
+
+// FractionWithOptions writes a number as a fraction as opts asks.
+//
+// e.g. FractionWithOptions(1.5, FractionOptions{ASCII: true}) -> 1 1/2
+// e.g. FractionWithOptions(3.14159, FractionOptions{MaxDenominator: 10}) -> 3⅐
+func FractionWithOptions(f float64, opts FractionOptions) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return Ftoa(f)
+	}
+	return formatFraction(new(big.Rat).SetFloat64(f), opts)
+}
+
+// BigFraction works like Fraction for big rationals.
+func BigFraction(r *big.Rat) string {
+	return formatFraction(r, FractionOptions{})
+}
+
+// BigFractionWithOptions works like FractionWithOptions for big
+// rationals.
+func BigFractionWithOptions(r *big.Rat, opts FractionOptions) string {
+	return formatFraction(r, opts)
+}
+
+var fractionRegex = regexp.MustCompile(`^([+\-]?)\s*(?:([0-9]+)(?:\s+([0-9]+)\s*/\s*([0-9]+))?|([0-9]+)\s*/\s*([0-9]+))$`)
+
+// ParseBigFraction parses a whole number, fraction or mixed number as
+// Fraction writes it, such as "1½", "1 1/2", "⅓" or "-3/4".
+func ParseBigFraction(s string) (*big.Rat, error) {
+	in := strings.Replace(s, "⁄", "/", -1)
+	in = strings.TrimSpace(vulgarFractionsReverse.Replace(in))
+	found := fractionRegex.FindStringSubmatch(in)
+	if found == nil {
+		return nil, fmt.Errorf("invalid fraction: %q", s)
+	}
+
+	whole, num, den := found[2], found[3], found[4]
+	if found[5] != "" {
+		whole, num, den = "0", found[5], found[6]
+	}
+	r, _ := new(big.Rat).SetString(whole)
+	if num != "" {
+		n, _ := new(big.Int).SetString(num, 10)
+		d, _ := new(big.Int).SetString(den, 10)
+		if d.Sign() == 0 {
+			return nil, fmt.Errorf("zero denominator in %q", s)
+		}
+		if found[5] == "" && n.Cmp(d) >= 0 {
+			return nil, fmt.Errorf("improper fraction in mixed number %q", s)
+		}
+		r.Add(r, new(big.Rat).SetFrac(n, d))
+	}
+	if found[1] == "-" {
+		r.Neg(r)
+	}
+	return r, nil
+}
+
+// ParseFraction works like ParseBigFraction, but returns a float64.
+//
+// e.g. ParseFraction("1½") -> 1.5, nil
+func ParseFraction(s string) (float64, error) {
+	r, err := ParseBigFraction(s)
+	if err != nil {
+		return 0, err
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
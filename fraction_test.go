@@ -0,0 +1,86 @@
+package humanize
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFraction(t *testing.T) {
+	testList{
+		{"half", Fraction(1.5), "1½"},
+		{"third", Fraction(0.333), "⅓"},
+		{"two thirds", Fraction(0.667), "⅔"},
+		{"quarter", Fraction(0.25), "¼"},
+		{"whole", Fraction(3), "3"},
+		{"zero", Fraction(0), "0"},
+		{"tiny", Fraction(0.01), "0"},
+		{"rounds up", Fraction(0.99), "1"},
+		{"no glyph", Fraction(2.1875), "2 3/16"},
+		{"negative", Fraction(-2.75), "-2¾"},
+		{"negative fraction", Fraction(-0.125), "-⅛"},
+		{"tenth", Fraction(0.1), "⅒"},
+		{"inf", Fraction(math.Inf(1)), "+Inf"},
+	}.validate(t)
+}
+
+func TestFractionWithOptions(t *testing.T) {
+	testList{
+		{"ascii", FractionWithOptions(1.5, FractionOptions{ASCII: true}), "1 1/2"},
+		{"ascii fraction", FractionWithOptions(0.333, FractionOptions{ASCII: true}), "1/3"},
+		{"pi 10", FractionWithOptions(math.Pi, FractionOptions{MaxDenominator: 10}), "3⅐"},
+		{"pi 100", FractionWithOptions(math.Pi, FractionOptions{MaxDenominator: 100}), "3 14/99"},
+		{"pi 1000", FractionWithOptions(math.Pi, FractionOptions{MaxDenominator: 1000}), "3 16/113"},
+		{"halves", FractionWithOptions(0.3, FractionOptions{MaxDenominator: 2}), "½"},
+		{"whole only", FractionWithOptions(2.4, FractionOptions{MaxDenominator: 1}), "2"},
+		{"semiconvergent", FractionWithOptions(0.7, FractionOptions{MaxDenominator: 4}), "⅔"},
+		{"convergent", FractionWithOptions(0.74, FractionOptions{MaxDenominator: 4}), "¾"},
+		{"big", BigFraction(big.NewRat(10, 4)), "2½"},
+		{"big limit", BigFractionWithOptions(big.NewRat(1000001, 3000000), FractionOptions{MaxDenominator: 10}), "⅓"},
+		{"big exact", BigFractionWithOptions(big.NewRat(-22, 7), FractionOptions{ASCII: true}), "-3 1/7"},
+	}.validate(t)
+}
+
+func TestParseFraction(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp float64
+	}{
+		{"1½", 1.5},
+		{"1 1/2", 1.5},
+		{"⅓", 1.0 / 3},
+		{"3/4", 0.75},
+		{"-2¾", -2.75},
+		{"- 2 3/16", -2.1875},
+		{"1⁄8", 0.125},
+		{"5", 5},
+		{"7/2", 3.5},
+		{" 2 ⅛ ", 2.125},
+	}
+	for _, test := range tests {
+		got, err := ParseFraction(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", "½½", "1/0", "1 3/2", "a/b", "1 1", "1.5"} {
+		if got, err := ParseFraction(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestFractionRoundTrip(t *testing.T) {
+	for _, r := range []*big.Rat{big.NewRat(3, 2), big.NewRat(-1, 3), big.NewRat(35, 16), big.NewRat(7, 1), big.NewRat(0, 1)} {
+		for _, opts := range []FractionOptions{{}, {ASCII: true}} {
+			s := BigFractionWithOptions(r, opts)
+			got, err := ParseBigFraction(s)
+			if err != nil || got.Cmp(r) != 0 {
+				t.Errorf("Round trip of %v through %q gave %v, %v", r, s, got, err)
+			}
+		}
+	}
+}
@@ -1,10 +1,26 @@
 package humanize
 
 import (
+	"math"
 	"strconv"
 	"strings"
 )
 
+// RoundingMode selects how a number is rounded to a given number of
+// digits.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, and ties to the
+	// value with an even last digit (2.25 -> 2.2, 2.35 -> 2.4).
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, and ties away from
+	// zero (2.25 -> 2.3, -2.25 -> -2.3).
+	RoundHalfUp
+	// RoundTowardZero drops the extra digits (2.29 -> 2.2).
+	RoundTowardZero
+)
+
 func stripTrailingZeros(s string) string {
 	if !strings.ContainsRune(s, '.') {
 		return s
@@ -23,27 +39,203 @@ func stripTrailingZeros(s string) string {
 	return s[:offset+1]
 }
 
-func stripTrailingDigits(s string, digits int) string {
-	if i := strings.Index(s, "."); i >= 0 {
-		if digits <= 0 {
-			return s[:i]
+// roundDecimal rounds the plain decimal number s (e.g. "-12.345") to
+// the given number of decimal places, padding it with zeros if needed.
+// Negative digits round to the left of the decimal point, so that
+// roundDecimal("1234", -2, RoundHalfEven) is "1200".
+func roundDecimal(s string, digits int, mode RoundingMode) string {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	all := []byte(intPart + frac)
+	point := len(intPart)
+	keep := point + digits
+	if keep < 1 {
+		// Make sure there is at least one digit to round into.
+		pad := []byte(strings.Repeat("0", 1-keep))
+		all = append(pad, all...)
+		point += len(pad)
+		keep = 1
+	}
+	if keep > len(all) {
+		all = append(all, strings.Repeat("0", keep-len(all))...)
+	}
+
+	kept, dropped := all[:keep], all[keep:]
+	roundUp := false
+	if len(dropped) > 0 {
+		switch mode {
+		case RoundHalfUp:
+			roundUp = dropped[0] >= '5'
+		case RoundHalfEven:
+			roundUp = dropped[0] > '5' ||
+				dropped[0] == '5' && (strings.Trim(string(dropped[1:]), "0") != "" ||
+					(kept[len(kept)-1]-'0')%2 == 1)
 		}
-		i++
-		if i+digits >= len(s) {
-			return s
+	}
+	if roundUp {
+		i := len(kept) - 1
+		for ; i >= 0 && kept[i] == '9'; i-- {
+			kept[i] = '0'
 		}
-		return s[:i+digits]
+		if i >= 0 {
+			kept[i]++
+		} else {
+			kept = append([]byte{'1'}, kept...)
+			point++
+		}
+	}
+
+	if len(kept) < point {
+		kept = append(kept, strings.Repeat("0", point-len(kept))...)
+	}
+	rv := strings.TrimLeft(string(kept[:point]), "0")
+	if rv == "" {
+		rv = "0"
+	}
+	if digits > 0 {
+		rv += "." + string(kept[point:])
+	}
+	if neg && strings.Trim(rv, "0.") != "" {
+		rv = "-" + rv
+	}
+	return rv
+}
+
+// decimalMagnitude returns the position of the first significant digit
+// of the plain decimal number s: the number of integer digits, or
+// minus the number of zeros following the decimal point.
+func decimalMagnitude(s string) int {
+	s = strings.TrimPrefix(s, "-")
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	if intPart = strings.TrimLeft(intPart, "0"); intPart != "" {
+		return len(intPart)
+	}
+	if trimmed := strings.TrimLeft(frac, "0"); trimmed != "" {
+		return len(trimmed) - len(frac)
+	}
+	return 1
+}
+
+// roundSignificant rounds the plain decimal number s to the given
+// number of significant figures.
+func roundSignificant(s string, digits int, mode RoundingMode) string {
+	if digits < 1 {
+		digits = 1
+	}
+	mag := decimalMagnitude(s)
+	rv := roundDecimal(s, digits-mag, mode)
+	if m := decimalMagnitude(rv); m > mag {
+		// Rounding carried into a new digit (9.99 -> 10.0), so
+		// drop the last one, which is always a zero.
+		rv = roundDecimal(rv, digits-m, mode)
+	}
+	return rv
+}
+
+// shiftDecimal returns the shortest decimal representation of
+// f*10^shift, without any rounding error from the multiplication.
+func shiftDecimal(f float64, shift int) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	e := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[e+1:])
+	digits := strings.Replace(s[:e], ".", "", 1)
+
+	point := 1 + exp + shift
+	switch {
+	case point <= 0:
+		digits = "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		digits += strings.Repeat("0", point-len(digits))
+	default:
+		digits = digits[:point] + "." + digits[point:]
+	}
+	return sign + digits
+}
+
+// movePoint returns the plain decimal number s multiplied by
+// 10^shift, without trailing zeros.
+func movePoint(s string, shift int) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
 	}
-	return s
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	digits := intPart + frac
+	point := len(intPart) + shift
+	switch {
+	case point <= 0:
+		digits = "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		digits += strings.Repeat("0", point-len(digits))
+	default:
+		digits = digits[:point] + "." + digits[point:]
+	}
+	digits = stripTrailingZeros(strings.TrimLeft(digits, "0"))
+	if digits == "" || digits[0] == '.' {
+		digits = "0" + digits
+	}
+	if digits == "0" {
+		return digits
+	}
+	return sign + digits
 }
 
 // Ftoa converts a float to a string with no trailing zeros.
+//
+// The value is rounded to six decimal places, or six significant
+// figures if it is smaller than that.
 func Ftoa(num float64) string {
-	return stripTrailingZeros(strconv.FormatFloat(num, 'f', 6, 64))
+	if math.IsInf(num, 0) || math.IsNaN(num) {
+		return strconv.FormatFloat(num, 'f', -1, 64)
+	}
+	digits := 6
+	if num != 0 && math.Abs(num) < 1e-6 {
+		digits = 5 - int(math.Floor(math.Log10(math.Abs(num))))
+	}
+	return FtoaWithRounding(num, digits, RoundHalfEven)
 }
 
 // FtoaWithDigits converts a float to a string but limits the resulting string
 // to the given number of decimal places, and no trailing zeros.
+//
+// The value is rounded to nearest, with ties to even.
+//
+// See also: FtoaWithRounding.
 func FtoaWithDigits(num float64, digits int) string {
-	return stripTrailingZeros(stripTrailingDigits(strconv.FormatFloat(num, 'f', 6, 64), digits))
+	return FtoaWithRounding(num, digits, RoundHalfEven)
+}
+
+// FtoaWithRounding works like FtoaWithDigits, rounding the value with
+// the given mode.
+//
+// e.g. FtoaWithRounding(2.25, 1, RoundHalfUp) -> 2.3
+// e.g. FtoaWithRounding(2.29, 1, RoundTowardZero) -> 2.2
+func FtoaWithRounding(num float64, digits int, mode RoundingMode) string {
+	s := strconv.FormatFloat(num, 'f', -1, 64)
+	if math.IsInf(num, 0) || math.IsNaN(num) {
+		return s
+	}
+	if digits < 0 {
+		digits = 0
+	}
+	return stripTrailingZeros(roundDecimal(s, digits, mode))
 }
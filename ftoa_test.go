@@ -2,13 +2,11 @@ package humanize
 
 import (
 	"fmt"
-	"math/rand"
-	"reflect"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
-	"testing/quick"
 )
 
 func TestFtoa(t *testing.T) {
@@ -19,6 +17,14 @@ func TestFtoa(t *testing.T) {
 		{"2.2", Ftoa(2.2), "2.2"},
 		{"2.02", Ftoa(2.02), "2.02"},
 		{"200.02", Ftoa(200.02), "200.02"},
+		{"0.1+0.2", Ftoa(0.1 + 0.2), "0.3"},
+		{"1.2345678", Ftoa(1.2345678), "1.234568"},
+		{"-0.0000001", Ftoa(-0.0000001), "-0.0000001"},
+		{"1.23456789e-9", Ftoa(1.23456789e-9), "0.00000000123457"},
+		{"1e21", Ftoa(1e21), "1000000000000000000000"},
+		{"1e300", Ftoa(1e300), "1" + strings.Repeat("0", 300)},
+		{"+Inf", Ftoa(math.Inf(1)), "+Inf"},
+		{"NaN", Ftoa(math.NaN()), "NaN"},
 	}.validate(t)
 }
 
@@ -29,62 +35,53 @@ func TestFtoaWithDigits(t *testing.T) {
 		{"1.23, 1", FtoaWithDigits(1.23, 1), "1.2"},
 		{"1.23, 2", FtoaWithDigits(1.23, 2), "1.23"},
 		{"1.23, 3", FtoaWithDigits(1.23, 3), "1.23"},
+		{"2.2399, 2", FtoaWithDigits(2.2399, 2), "2.24"},
+		{"2.225, 2", FtoaWithDigits(2.225, 2), "2.22"},
+		{"2.235, 2", FtoaWithDigits(2.235, 2), "2.24"},
+		{"9.996, 2", FtoaWithDigits(9.996, 2), "10"},
+		{"-9.996, 2", FtoaWithDigits(-9.996, 2), "-10"},
+		{"-0.001, 2", FtoaWithDigits(-0.001, 2), "0"},
+		{"1.23, -1", FtoaWithDigits(1.23, -1), "1"},
+		{"1e-7, 8", FtoaWithDigits(1e-7, 8), "0.0000001"},
+		{"1.23456789e-9, 10", FtoaWithDigits(1.23456789e-9, 10), "0.0000000012"},
+		{"1e22, 2", FtoaWithDigits(1e22, 2), "10000000000000000000000"},
 	}.validate(t)
 }
 
-func TestStripTrailingDigits(t *testing.T) {
-	err := quick.Check(func(s string, digits int) bool {
-		stripped := stripTrailingDigits(s, digits)
-
-		// A stripped string will always be a prefix of its original string
-		if !strings.HasPrefix(s, stripped) {
-			return false
-		}
-
-		if strings.ContainsRune(s, '.') {
-			// If there is a dot, the part on the left of the dot will never change
-			a := strings.Split(s, ".")
-			b := strings.Split(stripped, ".")
-			if a[0] != b[0] {
-				return false
-			}
-		} else {
-			// If there's no dot in the input, the output will always be the same as the input.
-			if stripped != s {
-				return false
-			}
-		}
-
-		return true
-	}, &quick.Config{
-		MaxCount: 10000,
-		Values: func(v []reflect.Value, r *rand.Rand) {
-			rdigs := func(n int) string {
-				digs := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
-				var rv []rune
-				for i := 0; i < n; i++ {
-					rv = append(rv, digs[r.Intn(len(digs))])
-				}
-				return string(rv)
-			}
-
-			ls := r.Intn(20)
-			rs := r.Intn(20)
-			jc := "."
-			if rs == 0 {
-				jc = ""
-			}
-			s := rdigs(ls) + jc + rdigs(rs)
-			digits := r.Intn(len(s) + 1)
-
-			v[0] = reflect.ValueOf(s)
-			v[1] = reflect.ValueOf(digits)
-		},
-	})
+func TestFtoaWithRounding(t *testing.T) {
+	testList{
+		{"2.25, half even", FtoaWithRounding(2.25, 1, RoundHalfEven), "2.2"},
+		{"2.35, half even", FtoaWithRounding(2.35, 1, RoundHalfEven), "2.4"},
+		{"2.251, half even", FtoaWithRounding(2.251, 1, RoundHalfEven), "2.3"},
+		{"2.25, half up", FtoaWithRounding(2.25, 1, RoundHalfUp), "2.3"},
+		{"-2.25, half up", FtoaWithRounding(-2.25, 1, RoundHalfUp), "-2.3"},
+		{"2.24, half up", FtoaWithRounding(2.24, 1, RoundHalfUp), "2.2"},
+		{"2.29, toward zero", FtoaWithRounding(2.29, 1, RoundTowardZero), "2.2"},
+		{"-2.29, toward zero", FtoaWithRounding(-2.29, 1, RoundTowardZero), "-2.2"},
+		{"0.5, half even", FtoaWithRounding(0.5, 0, RoundHalfEven), "0"},
+		{"1.5, half even", FtoaWithRounding(1.5, 0, RoundHalfEven), "2"},
+		{"0.5, half up", FtoaWithRounding(0.5, 0, RoundHalfUp), "1"},
+		{"999.95, half up", FtoaWithRounding(999.95, 1, RoundHalfUp), "1000"},
+	}.validate(t)
+}
 
-	if err != nil {
-		t.Error(err)
-	}
+func TestRoundDecimal(t *testing.T) {
+	testList{
+		{"pad", roundDecimal("1.2", 3, RoundHalfEven), "1.200"},
+		{"integer", roundDecimal("12", 2, RoundHalfEven), "12.00"},
+		{"tens", roundDecimal("1234", -1, RoundHalfEven), "1230"},
+		{"hundreds up", roundDecimal("1250.1", -2, RoundHalfEven), "1300"},
+		{"thousands carry", roundDecimal("9500", -3, RoundHalfEven), "10000"},
+		{"beyond", roundDecimal("123", -4, RoundHalfUp), "0"},
+		{"below one", roundDecimal(".05", 1, RoundHalfUp), "0.1"},
+		{"negative zero", roundDecimal("-0.04", 1, RoundHalfUp), "0.0"},
+		{"significant", roundSignificant("123456", 2, RoundHalfEven), "120000"},
+		{"significant small", roundSignificant("0.00123456", 3, RoundHalfEven), "0.00123"},
+		{"significant carry", roundSignificant("9.996", 3, RoundHalfEven), "10.0"},
+		{"shift", shiftDecimal(2.2e-9, 9), "2.2"},
+		{"shift left", shiftDecimal(-1234.5, -3), "-1.2345"},
+		{"shift right", shiftDecimal(1.5, 3), "1500"},
+	}.validate(t)
 }
 
 func BenchmarkFtoaRegexTrailing(b *testing.B) {
@@ -0,0 +1,303 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A MeasurementSystem is a system of units for Length, Mass and
+// Volume.
+type MeasurementSystem int
+
+// Measurement systems.
+const (
+	Metric MeasurementSystem = iota
+	USCustomary
+	Imperial
+)
+
+// measureUnit is a unit of length, mass or volume, with its size in
+// metres, kilograms or litres.
+type measureUnit struct {
+	symbol string
+	size   float64
+	// aliases are the other lower case names accepted when parsing.
+	aliases []string
+	// parseOnly units are accepted when parsing, but never chosen
+	// for formatting.
+	parseOnly bool
+}
+
+// measureTable holds the units of each system, smallest first.
+type measureTable map[MeasurementSystem][]measureUnit
+
+var metricLengths = []measureUnit{
+	{"mm", 0.001, []string{"millimeter", "millimeters", "millimetre", "millimetres"}, false},
+	{"cm", 0.01, []string{"centimeter", "centimeters", "centimetre", "centimetres"}, false},
+	{"m", 1, []string{"meter", "meters", "metre", "metres"}, false},
+	{"km", 1000, []string{"kilometer", "kilometers", "kilometre", "kilometres"}, false},
+}
+
+var customaryLengths = []measureUnit{
+	{"in", 0.0254, []string{"\"", "inch", "inches"}, false},
+	{"ft", 0.3048, []string{"'", "foot", "feet"}, false},
+	{"yd", 0.9144, []string{"yard", "yards"}, true},
+	{"mi", 1609.344, []string{"mile", "miles"}, false},
+}
+
+var lengthUnits = measureTable{
+	Metric:      metricLengths,
+	USCustomary: customaryLengths,
+	Imperial:    customaryLengths,
+}
+
+var metricMasses = []measureUnit{
+	{"mg", 1e-6, []string{"milligram", "milligrams"}, false},
+	{"g", 0.001, []string{"gram", "grams"}, false},
+	{"kg", 1, []string{"kilogram", "kilograms", "kilo", "kilos"}, false},
+	{"t", 1000, []string{"tonne", "tonnes"}, false},
+}
+
+var massUnits = measureTable{
+	Metric: metricMasses,
+	USCustomary: {
+		{"oz", 0.028349523125, []string{"ounce", "ounces"}, false},
+		{"lb", 0.45359237, []string{"lbs", "pound", "pounds"}, false},
+		{"ton", 907.18474, []string{"tons"}, false},
+	},
+	Imperial: {
+		{"oz", 0.028349523125, []string{"ounce", "ounces"}, false},
+		{"lb", 0.45359237, []string{"lbs", "pound", "pounds"}, false},
+		{"st", 6.35029318, []string{"stone", "stones"}, false},
+		{"ton", 1016.0469088, []string{"tons"}, false},
+	},
+}
+
+var metricVolumes = []measureUnit{
+	{"mL", 0.001, []string{"ml", "milliliter", "milliliters", "millilitre", "millilitres"}, false},
+	{"L", 1, []string{"l", "liter", "liters", "litre", "litres"}, false},
+	{"m³", 1000, []string{"m3", "cubic meter", "cubic meters", "cubic metre", "cubic metres"}, false},
+}
+
+var volumeUnits = measureTable{
+	Metric: metricVolumes,
+	USCustomary: {
+		{"fl oz", 0.0295735295625, []string{"floz", "fluid ounce", "fluid ounces"}, false},
+		{"cup", 0.2365882365, []string{"cups"}, true},
+		{"pt", 0.473176473, []string{"pint", "pints"}, false},
+		{"qt", 0.946352946, []string{"quart", "quarts"}, false},
+		{"gal", 3.785411784, []string{"gallon", "gallons"}, false},
+	},
+	Imperial: {
+		{"fl oz", 0.0284130625, []string{"floz", "fluid ounce", "fluid ounces"}, false},
+		{"pt", 0.56826125, []string{"pint", "pints"}, false},
+		{"qt", 1.1365225, []string{"quart", "quarts"}, false},
+		{"gal", 4.54609, []string{"gallon", "gallons"}, false},
+	},
+}
+
+// formatUnits returns the units of the system that may be chosen for
+// formatting.
+func (t measureTable) formatUnits(system MeasurementSystem) []measureUnit {
+	units, ok := t[system]
+	if !ok {
+		units = t[Metric]
+	}
+	var rv []measureUnit
+	for _, u := range units {
+		if !u.parseOnly {
+			rv = append(rv, u)
+		}
+	}
+	return rv
+}
+
+// pickUnit returns the index of the largest unit v is at least one of.
+func pickUnit(v float64, units []measureUnit) int {
+	i := 0
+	for j, u := range units {
+		if math.Abs(v) >= u.size {
+			i = j
+		}
+	}
+	return i
+}
+
+func formatMeasure(v float64, t measureTable, system MeasurementSystem) string {
+	units := t.formatUnits(system)
+	i := pickUnit(v, units)
+	s := FtoaWithDigits(v/units[i].size, 1)
+	if i+1 < len(units) {
+		// Rounding may have carried the value up to the next unit.
+		// The ratio of the units is rounded like the value, so that
+		// 12*0.0254 coming out just under 0.3048 still carries.
+		f, _ := strconv.ParseFloat(s, 64)
+		ratio, _ := strconv.ParseFloat(strconv.FormatFloat(units[i+1].size/units[i].size, 'g', 9, 64), 64)
+		if math.Abs(f) >= ratio {
+			i++
+			s = FtoaWithDigits(v/units[i].size, 1)
+		}
+	}
+	return s + " " + units[i].symbol
+}
+
+func formatCompoundMeasure(v float64, t measureTable, system MeasurementSystem) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return formatMeasure(v, t, system)
+	}
+	units := t.formatUnits(system)
+	i := pickUnit(v, units)
+	if system == Metric || i == 0 {
+		return formatMeasure(v, t, system)
+	}
+	major, minor := units[i], units[i-1]
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+
+	n := math.Floor(v / major.size)
+	rest := math.RoundToEven((v - n*major.size) / minor.size)
+	if rest*minor.size >= major.size-minor.size/2 {
+		n, rest = n+1, 0
+	}
+
+	switch {
+	case rest == 0:
+		return sign + Ftoa(n) + " " + major.symbol
+	case n == 0:
+		return sign + Ftoa(rest) + " " + minor.symbol
+	}
+	return sign + Ftoa(n) + " " + major.symbol + " " + Ftoa(rest) + " " + minor.symbol
+}
+
+var measureTermRegex = regexp.MustCompile(`^\s*([0-9]*\.?[0-9]+)\s*([^0-9.]*)`)
+
+func parseMeasure(s string, t measureTable, system MeasurementSystem) (float64, error) {
+	units := map[string]float64{}
+	for _, table := range [][]measureUnit{t[Metric], t[system]} {
+		for _, u := range table {
+			units[strings.ToLower(u.symbol)] = u.size
+			for _, a := range u.aliases {
+				units[a] = u.size
+			}
+		}
+	}
+
+	in := strings.TrimSpace(s)
+	sign := 1.0
+	if strings.HasPrefix(in, "-") {
+		sign, in = -1, in[1:]
+	}
+	if in == "" {
+		return 0, errInvalid
+	}
+
+	total := 0.0
+	for in != "" {
+		found := measureTermRegex.FindStringSubmatch(in)
+		if found == nil {
+			return 0, fmt.Errorf("invalid measurement: %v", s)
+		}
+		in = in[len(found[0]):]
+		name := strings.ToLower(strings.TrimSpace(found[2]))
+		size, ok := units[name]
+		if !ok {
+			return 0, fmt.Errorf("unhandled unit name: %q", name)
+		}
+		f, err := strconv.ParseFloat(found[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		total += f * size
+	}
+	return sign * total, nil
+}
+
+// Length produces a human-readable length in the most natural unit of
+// the system, given the length in metres.
+//
+// See also: CompoundLength, ParseLength.
+//
+// e.g. Length(1234, Metric) -> 1.2 km
+// e.g. Length(1, USCustomary) -> 3.3 ft
+func Length(meters float64, system MeasurementSystem) string {
+	return formatMeasure(meters, lengthUnits, system)
+}
+
+// CompoundLength works like Length, but writes US customary and
+// imperial lengths in two units.
+//
+// e.g. CompoundLength(1.016, USCustomary) -> 3 ft 4 in
+func CompoundLength(meters float64, system MeasurementSystem) string {
+	return formatCompoundMeasure(meters, lengthUnits, system)
+}
+
+// ParseLength parses a length written in metric units or those of the
+// system, possibly in several parts, and returns it in metres.
+//
+// e.g. ParseLength("1.2 km", Metric) -> 1200, nil
+// e.g. ParseLength("3 ft 4 in", USCustomary) -> 1.016, nil
+// e.g. ParseLength(`5'11"`, USCustomary) -> 1.8034, nil
+func ParseLength(s string, system MeasurementSystem) (float64, error) {
+	return parseMeasure(s, lengthUnits, system)
+}
+
+// Mass produces a human-readable mass in the most natural unit of the
+// system, given the mass in kilograms.
+//
+// See also: CompoundMass, ParseMass.
+//
+// e.g. Mass(0.25, Metric) -> 250 g
+// e.g. Mass(1, USCustomary) -> 2.2 lb
+func Mass(kilograms float64, system MeasurementSystem) string {
+	return formatMeasure(kilograms, massUnits, system)
+}
+
+// CompoundMass works like Mass, but writes US customary and imperial
+// masses in two units.
+//
+// e.g. CompoundMass(0.992, USCustomary) -> 2 lb 3 oz
+// e.g. CompoundMass(75, Imperial) -> 11 st 11 lb
+func CompoundMass(kilograms float64, system MeasurementSystem) string {
+	return formatCompoundMeasure(kilograms, massUnits, system)
+}
+
+// ParseMass parses a mass written in metric units or those of the
+// system, possibly in several parts, and returns it in kilograms.
+//
+// e.g. ParseMass("2 lb 3 oz", USCustomary) -> 0.9922..., nil
+func ParseMass(s string, system MeasurementSystem) (float64, error) {
+	return parseMeasure(s, massUnits, system)
+}
+
+// Volume produces a human-readable volume in the most natural unit of
+// the system, given the volume in litres.  US customary and imperial
+// units of volume differ.
+//
+// See also: CompoundVolume, ParseVolume.
+//
+// e.g. Volume(0.33, Metric) -> 330 mL
+// e.g. Volume(10, USCustomary) -> 2.6 gal
+func Volume(liters float64, system MeasurementSystem) string {
+	return formatMeasure(liters, volumeUnits, system)
+}
+
+// CompoundVolume works like Volume, but writes US customary and
+// imperial volumes in two units.
+//
+// e.g. CompoundVolume(5.68, USCustomary) -> 1 gal 2 qt
+func CompoundVolume(liters float64, system MeasurementSystem) string {
+	return formatCompoundMeasure(liters, volumeUnits, system)
+}
+
+// ParseVolume parses a volume written in metric units or those of the
+// system, possibly in several parts, and returns it in litres.
+//
+// e.g. ParseVolume("1 gal 2 qt", USCustomary) -> 5.678..., nil
+func ParseVolume(s string, system MeasurementSystem) (float64, error) {
+	return parseMeasure(s, volumeUnits, system)
+}
@@ -0,0 +1,123 @@
+package humanize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	testList{
+		{"km", Length(1234, Metric), "1.2 km"},
+		{"m", Length(1.5, Metric), "1.5 m"},
+		{"cm", Length(0.25, Metric), "25 cm"},
+		{"mm", Length(0.0042, Metric), "4.2 mm"},
+		{"zero", Length(0, Metric), "0 mm"},
+		{"carry", Length(999.97, Metric), "1 km"},
+		{"negative", Length(-1500, Metric), "-1.5 km"},
+		{"ft", Length(1, USCustomary), "3.3 ft"},
+		{"in", Length(0.1, USCustomary), "3.9 in"},
+		{"mi", Length(5000, Imperial), "3.1 mi"},
+		{"ft carry", Length(1609.335, USCustomary), "1 mi"},
+		{"in carry", Length(0.3047, USCustomary), "1 ft"},
+		{"in exact carry", Length(0.3048, USCustomary), "1 ft"},
+		{"in no carry", Length(0.3, USCustomary), "11.8 in"},
+	}.validate(t)
+}
+
+func TestCompoundLength(t *testing.T) {
+	testList{
+		{"3ft4in", CompoundLength(1.016, USCustomary), "3 ft 4 in"},
+		{"6ft", CompoundLength(1.8288, USCustomary), "6 ft"},
+		{"inches", CompoundLength(0.2, USCustomary), "7.9 in"},
+		{"carry", CompoundLength(1.82, Imperial), "6 ft"},
+		{"negative", CompoundLength(-1.016, USCustomary), "-3 ft 4 in"},
+		{"metric", CompoundLength(1.2, Metric), "1.2 m"},
+		{"inf", CompoundLength(math.Inf(1), USCustomary), "+Inf mi"},
+		{"nan", CompoundLength(math.NaN(), Imperial), "NaN in"},
+	}.validate(t)
+}
+
+func TestMass(t *testing.T) {
+	testList{
+		{"g", Mass(0.25, Metric), "250 g"},
+		{"t", Mass(1500, Metric), "1.5 t"},
+		{"lb", Mass(1, USCustomary), "2.2 lb"},
+		{"short ton", Mass(1000, USCustomary), "1.1 ton"},
+		{"stone", Mass(75, Imperial), "11.8 st"},
+		{"2lb3oz", CompoundMass(0.992, USCustomary), "2 lb 3 oz"},
+		{"11st11lb", CompoundMass(75, Imperial), "11 st 11 lb"},
+		{"oz", CompoundMass(0.1, USCustomary), "3.5 oz"},
+	}.validate(t)
+}
+
+func TestVolume(t *testing.T) {
+	testList{
+		{"mL", Volume(0.33, Metric), "330 mL"},
+		{"L", Volume(2, Metric), "2 L"},
+		{"m³", Volume(2500, Metric), "2.5 m³"},
+		{"US gal", Volume(10, USCustomary), "2.6 gal"},
+		{"imperial gal", Volume(10, Imperial), "2.2 gal"},
+		{"fl oz", Volume(0.25, Imperial), "8.8 fl oz"},
+		{"compound", CompoundVolume(5.68, USCustomary), "1 gal 2 qt"},
+	}.validate(t)
+}
+
+func TestParseMeasure(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string, MeasurementSystem) (float64, error)
+		in     string
+		system MeasurementSystem
+		exp    float64
+	}{
+		{"km", ParseLength, "1.2 km", Metric, 1200},
+		{"compound", ParseLength, "3 ft 4 in", USCustomary, 1.016},
+		{"quotes", ParseLength, `5'11"`, USCustomary, 1.8034},
+		{"words", ParseLength, "2 feet 1 inch", Imperial, 0.635},
+		{"yd", ParseLength, "2 yd", USCustomary, 1.8288},
+		{"metric in other", ParseLength, "3 m", USCustomary, 3},
+		{"negative", ParseLength, "-1.5 km", Metric, -1500},
+		{"lb oz", ParseMass, "2 lb 3 oz", USCustomary, 0.99223331},
+		{"stone", ParseMass, "11 st 11 lb", Imperial, 74.842741},
+		{"short ton", ParseMass, "1 ton", USCustomary, 907.18474},
+		{"long ton", ParseMass, "1 ton", Imperial, 1016.0469088},
+		{"US gal", ParseVolume, "1 gal 2 qt", USCustomary, 5.678117676},
+		{"imperial pint", ParseVolume, "1 pint", Imperial, 0.56826125},
+		{"fl oz", ParseVolume, "12 fl oz", USCustomary, 0.354882355},
+		{"mL", ParseVolume, "330ml", Metric, 0.33},
+	}
+
+	for _, test := range tests {
+		got, err := test.parse(test.in, test.system)
+		if err != nil {
+			t.Errorf("%v: error parsing %q: %v", test.name, test.in, err)
+			continue
+		}
+		if math.Abs(got-test.exp) > 1e-6*math.Abs(test.exp) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.exp, got)
+		}
+	}
+
+	for _, bad := range []string{"", "12", "3 parsecs", "ft 3", "3 ft 4", "-"} {
+		if got, err := ParseLength(bad, USCustomary); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+	if got, err := ParseMass("2 st", USCustomary); err == nil {
+		t.Errorf("Expected error parsing stone in US customary units, got %v", got)
+	}
+}
+
+func TestMeasureRoundTrip(t *testing.T) {
+	for _, m := range []float64{0.3, 1.016, 1.8288, 1609.344} {
+		s := CompoundLength(m, USCustomary)
+		got, err := ParseLength(s, USCustomary)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", s, err)
+			continue
+		}
+		if math.Abs(got-m) > 0.0254 {
+			t.Errorf("Round trip of %v through %q gave %v", m, s, got)
+		}
+	}
+}
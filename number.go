@@ -9,38 +9,680 @@ Source: https://gist.github.com/gorhill/5285193
 */
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
-var (
-	renderFloatPrecisionMultipliers = [...]float64{
-		1,
-		10,
-		100,
-		1000,
-		10000,
-		100000,
-		1000000,
-		10000000,
-		100000000,
-		1000000000,
-	}
-
-	renderFloatPrecisionRounders = [...]float64{
-		0.5,
-		0.05,
-		0.005,
-		0.0005,
-		0.00005,
-		0.000005,
-		0.0000005,
-		0.00000005,
-		0.000000005,
-		0.0000000005,
-	}
+// Sign placeholders in compiled prefixes and suffixes.  plusMark
+// becomes the sign of the number, and minusMark a minus sign for
+// negative numbers only.
+const (
+	plusMark  = '\uE000'
+	minusMark = '\uE001'
+)
+
+// padPosition is where a NumberFormat pads numbers to its width.
+type padPosition int
+
+const (
+	padBeforePrefix padPosition = iota
+	padAfterPrefix
+	padBeforeSuffix
+	padAfterSuffix
 )
 
+// NumberFormat is a compiled number pattern.  It is immutable, so one
+// NumberFormat may be used from many goroutines.
+//
+// See also: CompileNumberFormat.
+type NumberFormat struct {
+	prefix, suffix       string
+	negPrefix, negSuffix string
+
+	minInt           int
+	minFrac, maxFrac int
+	// groupSize is the number of digits in the group next to the
+	// decimal separator, and secondaryGroup the number in the others,
+	// as in "#,##,##0" for 12,34,567.  No grouping when zero.
+	groupSize, secondaryGroup int
+
+	// exponent writes numbers in scientific notation, with at least
+	// minExp exponent digits.  When maxInt, the number of whole digits
+	// in the pattern, is more than minInt, the exponent is a multiple
+	// of it.
+	exponent bool
+	expPlus  bool
+	minExp   int
+	maxInt   int
+
+	// shift is the power of ten numbers are multiplied by: 2 for
+	// percent and 3 for per mille.
+	shift int
+
+	// width is the number of runes numbers are padded to with pad.
+	width int
+	pad   string
+	padAt padPosition
+
+	decimal, group string
+	rounding       RoundingMode
+}
+
+// numberSection is one side of a positive;negative pattern.
+type numberSection struct {
+	prefix, number, suffix string
+	hasSign                bool
+	shift                  int
+
+	hasPad bool
+	pad    rune
+	padAt  padPosition
+}
+
+// splitPattern splits a pattern at the semicolons outside quotes.
+func splitPattern(pattern string) []string {
+	var sections []string
+	quote, escaped := rune(0), false
+	start := 0
+	for i, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\\':
+			escaped = true
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			sections = append(sections, pattern[start:i])
+			start = i + 1
+		}
+	}
+	return append(sections, pattern[start:])
+}
+
+// parseSection splits one side of a pattern into its prefix, number
+// and suffix.
+func parseSection(section string) (numberSection, error) {
+	var rv numberSection
+	var prefix, number, suffix strings.Builder
+	runes := []rune(section)
+	state := 0 // 0: prefix, 1: number, 2: suffix
+
+	write := func(s string) error {
+		if state == 0 {
+			if rv.hasPad && rv.padAt == padAfterPrefix {
+				return errors.New("misplaced padding")
+			}
+			prefix.WriteString(s)
+			return nil
+		}
+		if rv.hasPad && rv.padAt == padAfterSuffix {
+			return errors.New("misplaced padding")
+		}
+		state = 2
+		suffix.WriteString(s)
+		return nil
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var err error
+		switch {
+		case r == '\'' || r == '"':
+			end := i + 1
+			for ; end < len(runes); end++ {
+				if runes[end] == r {
+					if r == '\'' && end+1 < len(runes) && runes[end+1] == '\'' {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			if end == len(runes) {
+				return rv, errors.New("unterminated quote")
+			}
+			if r == '\'' && end == i+1 {
+				err = write("'")
+			} else {
+				err = write(strings.Replace(string(runes[i+1:end]), "''", "'", -1))
+			}
+			i = end
+		case r == '\\':
+			if i+1 == len(runes) {
+				return rv, errors.New("trailing backslash")
+			}
+			i++
+			err = write(string(runes[i]))
+		case r == '*':
+			if rv.hasPad {
+				return rv, errors.New("more than one padding")
+			}
+			if i+1 == len(runes) {
+				return rv, errors.New("missing padding character")
+			}
+			switch {
+			case state == 0 && prefix.Len() == 0:
+				rv.padAt = padBeforePrefix
+			case state == 0:
+				rv.padAt = padAfterPrefix
+			case suffix.Len() == 0:
+				rv.padAt = padBeforeSuffix
+				state = 2
+			default:
+				rv.padAt = padAfterSuffix
+			}
+			i++
+			rv.hasPad, rv.pad = true, runes[i]
+		case r == '#' || r == '0' || r == ',' || r == '.':
+			if state == 2 {
+				return rv, fmt.Errorf("unexpected %q after the number", r)
+			}
+			state = 1
+			number.WriteRune(r)
+		case r == 'E' && state == 1:
+			end := i + 1
+			if end < len(runes) && runes[end] == '+' {
+				end++
+			}
+			zeros := end
+			for zeros < len(runes) && runes[zeros] == '0' {
+				zeros++
+			}
+			if zeros == end {
+				err = write("E")
+				break
+			}
+			number.WriteString(string(runes[i:zeros]))
+			state = 2
+			i = zeros - 1
+		case r >= '1' && r <= '9':
+			return rv, fmt.Errorf("unsupported digit %q", r)
+		case r == '+':
+			err = write(string(plusMark))
+			rv.hasSign = true
+		case r == '-':
+			err = write(string(minusMark))
+			rv.hasSign = true
+		case r == '%' || r == '‰':
+			shift := 2
+			if r == '‰' {
+				shift = 3
+			}
+			if rv.shift != 0 && rv.shift != shift {
+				return rv, errors.New("both percent and per mille")
+			}
+			rv.shift = shift
+			err = write(string(r))
+		default:
+			err = write(string(r))
+		}
+		if err != nil {
+			return rv, err
+		}
+	}
+	rv.prefix, rv.number, rv.suffix = prefix.String(), number.String(), suffix.String()
+	return rv, nil
+}
+
+// CompileNumberFormat compiles a number pattern such as "#,##0.00",
+// following the common subset of ICU and Excel number patterns.
+//
+// A pattern is a number, optionally with literal text before and
+// after it:
+//
+//	#   a digit, left out when it would be a leading or trailing zero
+//	0   a digit, always written
+//	,   the grouping separator; the number of digits between the last
+//	    one and the decimal separator is the grouping size, and the
+//	    number between the last two, if different, the size of the
+//	    other groups
+//	.   the decimal separator
+//	E   the exponent in scientific notation, followed by an optional
+//	    + and the minimum number of exponent digits as zeros
+//	+   in the text, the sign of the number
+//	-   in the text, a minus sign for negative numbers
+//	%   in the text, multiplies the number by 100
+//	‰   in the text, multiplies the number by 1000
+//	*   pads the number to the width of the pattern with the
+//	    character after it, at the start or end of the text
+//	;   separates the pattern for positive numbers from the text for
+//	    negative ones
+//	'   quotes literal text; '' is a quote
+//	"   quotes literal text, as in Excel
+//	\   quotes the character after it
+//
+// Zeros come after the #s in the whole part ("#,##0") and before them
+// in the fraction ("0.00##"), which may have any number of digits.
+// In scientific notation, #s in the whole part make the exponent a
+// multiple of their number, and the number is written with as many
+// significant digits as the pattern has zeros and fraction digits.
+//
+// Without a + or - in the text, or a pattern for negative numbers,
+// negative numbers start with a minus sign.  The digits of a pattern
+// for negative numbers are ignored.  Numbers are rounded with ties to
+// even, and written with "." and "," unless WithRounding and
+// WithSeparators say otherwise.
+//
+// e.g. CompileNumberFormat("#,##0.00") formats 1234.5 as 1,234.50
+// e.g. CompileNumberFormat("+0.0 'dB'") formats 3 as +3.0 dB
+// e.g. CompileNumberFormat("#,##,##0") formats 1234567 as 12,34,567
+// e.g. CompileNumberFormat("0.0%") formats 0.256 as 25.6%
+// e.g. CompileNumberFormat("0.00E+00") formats 1234.5 as 1.23E+03
+// e.g. CompileNumberFormat("##0.##E0") formats 12345 as 12.3E3
+// e.g. CompileNumberFormat("$*x#,##0.00") formats 123 as $xx123.00
+// e.g. CompileNumberFormat("#,##0.00;(#,##0.00)") formats -5 as (5.00)
+func CompileNumberFormat(pattern string) (*NumberFormat, error) {
+	sections := splitPattern(pattern)
+	if len(sections) > 2 {
+		return nil, fmt.Errorf("more than two sub-patterns in %q", pattern)
+	}
+	pos, err := parseSection(sections[0])
+	if err != nil {
+		return nil, fmt.Errorf("%v in %q", err, pattern)
+	}
+
+	f := &NumberFormat{decimal: ".", group: ",", shift: pos.shift}
+	if err := f.compileNumber(pos.number); err != nil {
+		return nil, fmt.Errorf("%v in %q", err, pattern)
+	}
+	f.prefix, f.suffix = pos.prefix, pos.suffix
+	f.negPrefix, f.negSuffix = pos.prefix, pos.suffix
+	if !pos.hasSign {
+		f.negPrefix = string(minusMark) + f.negPrefix
+	}
+	if pos.hasPad {
+		f.width = utf8.RuneCountInString(pos.prefix + pos.number + pos.suffix)
+		f.pad, f.padAt = string(pos.pad), pos.padAt
+	}
+
+	if len(sections) == 2 {
+		neg, err := parseSection(sections[1])
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("%v in %q", err, pattern)
+		case !strings.ContainsAny(neg.number, "#0"):
+			return nil, fmt.Errorf("missing digits in the negative sub-pattern of %q", pattern)
+		case neg.hasPad:
+			return nil, fmt.Errorf("padding in the negative sub-pattern of %q", pattern)
+		case neg.shift != 0 && neg.shift != f.shift:
+			return nil, fmt.Errorf("percent or per mille in only one sub-pattern of %q", pattern)
+		}
+		f.negPrefix, f.negSuffix = neg.prefix, neg.suffix
+	}
+	return f, nil
+}
+
+// compileNumber reads the digits and separators of a pattern.
+func (f *NumberFormat) compileNumber(number string) error {
+	if i := strings.IndexByte(number, 'E'); i >= 0 {
+		exp := number[i+1:]
+		number = number[:i]
+		f.exponent = true
+		f.expPlus = strings.HasPrefix(exp, "+")
+		f.minExp = len(strings.TrimPrefix(exp, "+"))
+	}
+	if !strings.ContainsAny(number, "#0") {
+		return errors.New("missing digits")
+	}
+	intPart, frac := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		intPart, frac = number[:i], number[i+1:]
+	}
+	if strings.ContainsAny(frac, ".,") {
+		return errors.New("misplaced separator")
+	}
+	if strings.Contains(frac, "#0") {
+		return errors.New("misplaced 0 in the fraction")
+	}
+	f.minFrac = strings.Count(frac, "0")
+	f.maxFrac = len(frac)
+
+	groups := strings.Split(intPart, ",")
+	digits := strings.Join(groups, "")
+	if strings.Contains(digits, "0#") {
+		return errors.New("misplaced # in the whole part")
+	}
+	f.minInt = strings.Count(digits, "0")
+	f.maxInt = len(digits)
+	if len(groups) > 1 {
+		if f.exponent {
+			return errors.New("grouping in scientific notation")
+		}
+		for _, g := range groups {
+			if g == "" {
+				return errors.New("misplaced separator")
+			}
+		}
+		f.groupSize = len(groups[len(groups)-1])
+		if len(groups) > 2 {
+			f.secondaryGroup = len(groups[len(groups)-2])
+		}
+	}
+	return nil
+}
+
+// WithSeparators returns a copy of the format that writes the given
+// decimal and grouping separators.
+//
+// e.g. WithSeparators(",", ".") formats 1234.5 as 1.234,50
+func (f *NumberFormat) WithSeparators(decimal, group string) *NumberFormat {
+	c := *f
+	c.decimal, c.group = decimal, group
+	return &c
+}
+
+// WithRounding returns a copy of the format that rounds with the
+// given mode.
+func (f *NumberFormat) WithRounding(mode RoundingMode) *NumberFormat {
+	c := *f
+	c.rounding = mode
+	return &c
+}
+
+// groupDigits inserts the grouping separator into the whole number s.
+func (f *NumberFormat) groupDigits(s string) string {
+	if f.groupSize <= 0 || len(s) <= f.groupSize {
+		return s
+	}
+	size := f.secondaryGroup
+	if size <= 0 {
+		size = f.groupSize
+	}
+	head, tail := s[:len(s)-f.groupSize], s[len(s)-f.groupSize:]
+	var groups []string
+	for len(head) > size {
+		groups = append([]string{head[len(head)-size:]}, groups...)
+		head = head[:len(head)-size]
+	}
+	groups = append([]string{head}, groups...)
+	return strings.Join(append(groups, tail), f.group)
+}
+
+// affix writes the sign placeholders of a prefix or suffix for a
+// number with the given sign.
+func affix(s string, sign int) string {
+	plus, minus := "", ""
+	switch {
+	case sign > 0:
+		plus = "+"
+	case sign < 0:
+		plus, minus = "-", "-"
+	}
+	return strings.NewReplacer(string(plusMark), plus, string(minusMark), minus).Replace(s)
+}
+
+// fixed writes the non-negative plain decimal number s with between
+// minFrac and maxFrac decimal places.
+func (f *NumberFormat) fixed(s string, minFrac, maxFrac int) string {
+	s = roundDecimal(s, maxFrac, f.rounding)
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	for len(frac) > minFrac && frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if len(intPart) < f.minInt {
+		intPart = strings.Repeat("0", f.minInt-len(intPart)) + intPart
+	}
+	if intPart == "" && frac == "" {
+		intPart = "0"
+	}
+
+	rv := f.groupDigits(intPart)
+	if frac != "" {
+		rv += f.decimal + frac
+	}
+	return rv
+}
+
+// scientific writes the non-negative plain decimal number s in
+// scientific notation.
+func (f *NumberFormat) scientific(s string) string {
+	exp := 0
+	var mantissa string
+	switch {
+	case strings.Trim(s, "0.") == "":
+		mantissa = f.fixed("0", f.minFrac, f.maxFrac)
+	case f.maxInt > f.minInt:
+		// Engineering notation: the exponent is a multiple of maxInt,
+		// and the number has a fixed number of significant digits.
+		sig := f.minInt + f.maxFrac
+		if sig < 1 {
+			sig = 1
+		}
+		r := roundSignificant(s, sig, f.rounding)
+		mag := decimalMagnitude(r)
+		exp = (mag - 1) / f.maxInt * f.maxInt
+		if mag-1 < 0 && (mag-1)%f.maxInt != 0 {
+			exp -= f.maxInt
+		}
+		maxFrac := sig - (mag - exp)
+		if maxFrac < 0 {
+			maxFrac = 0
+		}
+		minFrac := f.minFrac
+		if minFrac > maxFrac {
+			minFrac = maxFrac
+		}
+		mantissa = f.fixed(movePoint(r, -exp), minFrac, maxFrac)
+	default:
+		intDigits := f.minInt
+		if intDigits < 1 {
+			intDigits = 1
+		}
+		exp = decimalMagnitude(s) - intDigits
+		m := roundDecimal(movePoint(s, -exp), f.maxFrac, f.rounding)
+		if decimalMagnitude(m) > intDigits {
+			// Rounding carried into a new digit (9.99 -> 10.0).
+			exp++
+			m = movePoint(m, -1)
+		}
+		mantissa = f.fixed(m, f.minFrac, f.maxFrac)
+	}
+
+	sign := ""
+	switch {
+	case exp < 0:
+		sign, exp = "-", -exp
+	case f.expPlus:
+		sign = "+"
+	}
+	digits := strconv.Itoa(exp)
+	if len(digits) < f.minExp {
+		digits = strings.Repeat("0", f.minExp-len(digits)) + digits
+	}
+	return mantissa + "E" + sign + digits
+}
+
+// format writes the non-negative plain decimal number s, with the
+// given sign.
+func (f *NumberFormat) format(sign int, s string) string {
+	if f.shift != 0 {
+		s = movePoint(s, f.shift)
+	}
+	var number string
+	if f.exponent {
+		number = f.scientific(s)
+	} else {
+		number = f.fixed(s, f.minFrac, f.maxFrac)
+	}
+
+	prefix, suffix := f.prefix, f.suffix
+	if sign < 0 {
+		prefix, suffix = f.negPrefix, f.negSuffix
+	}
+	prefix, suffix = affix(prefix, sign), affix(suffix, sign)
+
+	n := utf8.RuneCountInString(prefix) + utf8.RuneCountInString(number) + utf8.RuneCountInString(suffix)
+	if n >= f.width {
+		return prefix + number + suffix
+	}
+	pad := strings.Repeat(f.pad, f.width-n)
+	switch f.padAt {
+	case padBeforePrefix:
+		prefix = pad + prefix
+	case padAfterPrefix:
+		prefix += pad
+	case padBeforeSuffix:
+		suffix = pad + suffix
+	default:
+		suffix += pad
+	}
+	return prefix + number + suffix
+}
+
+// FormatFloat formats a float64.  NaN and infinities are written
+// "NaN", "Infinity" and "-Infinity".
+func (f *NumberFormat) FormatFloat(n float64) string {
+	switch {
+	case math.IsNaN(n):
+		return "NaN"
+	case math.IsInf(n, 1):
+		return "Infinity"
+	case math.IsInf(n, -1):
+		return "-Infinity"
+	}
+	sign := 0
+	switch {
+	case n > 0:
+		sign = 1
+	case n < 0:
+		sign = -1
+	}
+	return f.format(sign, strconv.FormatFloat(math.Abs(n), 'f', -1, 64))
+}
+
+// FormatInt formats an int64 exactly.
+func (f *NumberFormat) FormatInt(n int64) string {
+	sign := 0
+	switch {
+	case n > 0:
+		sign = 1
+	case n < 0:
+		sign = -1
+	}
+	return f.format(sign, strings.TrimPrefix(strconv.FormatInt(n, 10), "-"))
+}
+
+// FormatUint formats a uint64 exactly.
+func (f *NumberFormat) FormatUint(n uint64) string {
+	sign := 0
+	if n > 0 {
+		sign = 1
+	}
+	return f.format(sign, strconv.FormatUint(n, 10))
+}
+
+// FormatBigInt formats a big integer exactly.
+func (f *NumberFormat) FormatBigInt(n *big.Int) string {
+	return f.format(n.Sign(), new(big.Int).Abs(n).String())
+}
+
+// FormatBigFloat formats a big float with all its digits.
+// Infinities are written "Infinity" and "-Infinity".
+func (f *NumberFormat) FormatBigFloat(n *big.Float) string {
+	if n.IsInf() {
+		if n.Sign() < 0 {
+			return "-Infinity"
+		}
+		return "Infinity"
+	}
+	return f.format(n.Sign(), strings.TrimPrefix(n.Text('f', -1), "-"))
+}
+
+// legacyNumberFormat compiles a FormatFloat format string, in which
+// the separators are told apart by their places rather than by the
+// characters used.
+func legacyNumberFormat(format string) (*NumberFormat, error) {
+	// default format
+	f := &NumberFormat{
+		negPrefix: string(minusMark),
+		minInt:    1,
+		minFrac:   2,
+		maxFrac:   2,
+		groupSize: 3,
+		decimal:   ".",
+		group:     ",",
+		rounding:  RoundHalfUp,
+	}
+	if len(format) == 0 {
+		return f, nil
+	}
+	runes := []rune(format)
+
+	// If there is an explicit format directive,
+	// then default values are these:
+	f.minFrac, f.maxFrac = 9, 9
+	f.groupSize = 0
+
+	// collect indices of meaningful formatting directives
+	formatIndx := []int{}
+	for i, char := range runes {
+		if char != '#' && char != '0' {
+			formatIndx = append(formatIndx, i)
+		}
+	}
+
+	if len(formatIndx) > 0 {
+		// Directive at index 0:
+		//   Must be a '+'
+		//   Raise an error if not the case
+		// index: 0123456789
+		//        +0.000,000
+		//        +000,000.0
+		//        +0000.00
+		//        +0000
+		if formatIndx[0] == 0 {
+			if runes[formatIndx[0]] != '+' {
+				return nil, errors.New("FormatFloat(): invalid positive sign directive")
+			}
+			f.prefix, f.negPrefix = string(plusMark), string(plusMark)
+			formatIndx = formatIndx[1:]
+		}
+
+		// Two directives:
+		//   First is thousands separator
+		//   Raise an error if not followed by 3-digit
+		// 0123456789
+		// 0.000,000
+		// 000,000.00
+		if len(formatIndx) == 2 {
+			if (formatIndx[1] - formatIndx[0]) != 4 {
+				return nil, errors.New("FormatFloat(): thousands separator directive must be followed by 3 digit-specifiers")
+			}
+			f.group = string(runes[formatIndx[0]])
+			f.groupSize = 3
+			formatIndx = formatIndx[1:]
+		}
+
+		// One directive:
+		//   Directive is decimal separator
+		//   The number of digit-specifier following the separator indicates wanted precision
+		// 0123456789
+		// 0.00
+		// 000,0000
+		if len(formatIndx) == 1 {
+			f.decimal = string(runes[formatIndx[0]])
+			f.minFrac = len(runes) - formatIndx[0] - 1
+			f.maxFrac = f.minFrac
+		}
+	}
+	return f, nil
+}
+
 // FormatFloat produces a formatted number as string based on the following user-specified criteria:
 //
 // * thousands separator
@@ -56,138 +698,321 @@ var (
 // "#,###.##" => "12,345.67"
 // "#,###." => "12,345"
 // "#,###" => "12345,678"
-// "#\u202F###,##" => "12 345,68"
+// "# ###,##" => "12 345,68"
 // "#.###,###### => 12.345,678900
 // "" (aka default format) => 12,345.67
 //
-// The highest precision allowed is 9 digits after the decimal symbol.
+// Any number of digits may follow the decimal symbol.  Numbers are
+// rounded half up, and those closer to zero than 0.000000001 are
+// written as zero.  FormatFloat panics on invalid format strings.
 // There is also a version for integer number, FormatInteger(),
 // which is convenient for calls within template.
+//
+// See also: CompileNumberFormat, which formats a number with a
+// pattern compiled once.
 func FormatFloat(format string, n float64) string {
-	// Special cases:
-	//   NaN = "NaN"
-	//   +Inf = "+Infinity"
-	//   -Inf = "-Infinity"
-	if math.IsNaN(n) {
-		return "NaN"
+	f := mustLegacyNumberFormat(format)
+	if math.Abs(n) < 0.000000001 {
+		n = 0
 	}
-	if n > math.MaxFloat64 {
-		return "Infinity"
+	return f.FormatFloat(n)
+}
+
+// legacyNumberFormats caches the compiled formats of FormatFloat and
+// the FormatInteger functions by format string.
+var legacyNumberFormats sync.Map
+
+// mustLegacyNumberFormat is legacyNumberFormat, panicking on invalid
+// format strings.
+func mustLegacyNumberFormat(format string) *NumberFormat {
+	if f, ok := legacyNumberFormats.Load(format); ok {
+		return f.(*NumberFormat)
 	}
-	if n < (0.0 - math.MaxFloat64) {
-		return "-Infinity"
+	f, err := legacyNumberFormat(format)
+	if err != nil {
+		panic(err.Error())
 	}
+	legacyNumberFormats.Store(format, f)
+	return f
+}
 
-	// default format
-	precision := 2
-	decimalStr := "."
-	thousandStr := ","
-	positiveStr := ""
-	negativeStr := "-"
-
-	if len(format) > 0 {
-		format := []rune(format)
-
-		// If there is an explicit format directive,
-		// then default values are these:
-		precision = 9
-		thousandStr = ""
-
-		// collect indices of meaningful formatting directives
-		formatIndx := []int{}
-		for i, char := range format {
-			if char != '#' && char != '0' {
-				formatIndx = append(formatIndx, i)
-			}
-		}
-
-		if len(formatIndx) > 0 {
-			// Directive at index 0:
-			//   Must be a '+'
-			//   Raise an error if not the case
-			// index: 0123456789
-			//        +0.000,000
-			//        +000,000.0
-			//        +0000.00
-			//        +0000
-			if formatIndx[0] == 0 {
-				if format[formatIndx[0]] != '+' {
-					panic("FormatFloat(): invalid positive sign directive")
-				}
-				positiveStr = "+"
-				formatIndx = formatIndx[1:]
-			}
-
-			// Two directives:
-			//   First is thousands separator
-			//   Raise an error if not followed by 3-digit
-			// 0123456789
-			// 0.000,000
-			// 000,000.00
-			if len(formatIndx) == 2 {
-				if (formatIndx[1] - formatIndx[0]) != 4 {
-					panic("FormatFloat(): thousands separator directive must be followed by 3 digit-specifiers")
+// FormatInteger produces a formatted number as string.
+// See FormatFloat.
+func FormatInteger(format string, n int) string {
+	return FormatInteger64(format, int64(n))
+}
+
+// FormatInteger64 works like FormatInteger for int64s, keeping every
+// digit of numbers too large for a float64.
+//
+// e.g. FormatInteger64("", 9007199254740993) -> 9,007,199,254,740,993.00
+func FormatInteger64(format string, n int64) string {
+	return mustLegacyNumberFormat(format).FormatInt(n)
+}
+
+// FormatIntegerUint64 works like FormatInteger64 for uint64s.
+func FormatIntegerUint64(format string, n uint64) string {
+	return mustLegacyNumberFormat(format).FormatUint(n)
+}
+
+// BigFormatInteger works like FormatInteger64 for big integers.
+func BigFormatInteger(format string, n *big.Int) string {
+	return mustLegacyNumberFormat(format).FormatBigInt(n)
+}
+
+// NumberLocale names the separators numbers are written with.
+type NumberLocale struct {
+	Decimal, Group string
+}
+
+// Common number locales.  Numbers grouped with a space may be parsed
+// with any of the spaces used for grouping: " ", "\u00a0" and
+// "\u202f".
+var (
+	NumberEnglish = NumberLocale{Decimal: ".", Group: ","}
+	NumberGerman  = NumberLocale{Decimal: ",", Group: "."}
+	NumberFrench  = NumberLocale{Decimal: ",", Group: "\u202f"}
+	NumberSwiss   = NumberLocale{Decimal: ".", Group: "’"}
+)
+
+// spaceGroups are the spaces numbers are grouped with.
+var spaceGroups = []string{" ", "\u00a0", "\u202f"}
+
+// groupAt returns the length of the grouping separator at the start
+// of s, or 0 if there is none.
+func (f *NumberFormat) groupAt(s string) int {
+	if f.group == "" {
+		return 0
+	}
+	if strings.HasPrefix(s, f.group) {
+		return len(f.group)
+	}
+	for _, g := range spaceGroups {
+		if g == f.group {
+			for _, space := range spaceGroups {
+				if strings.HasPrefix(s, space) {
+					return len(space)
 				}
-				thousandStr = string(format[formatIndx[0]])
-				formatIndx = formatIndx[1:]
 			}
+		}
+	}
+	return 0
+}
 
-			// One directive:
-			//   Directive is decimal separator
-			//   The number of digit-specifier following the separator indicates wanted precision
-			// 0123456789
-			// 0.00
-			// 000,0000
-			if len(formatIndx) == 1 {
-				decimalStr = string(format[formatIndx[0]])
-				precision = len(format) - formatIndx[0] - 1
+// parseDigits reads the digits and separators of a number without its
+// affixes, checking that the groups are where the format puts them,
+// and returns it as a plain decimal number.
+func (f *NumberFormat) parseDigits(s string) (string, bool) {
+	var groups []string
+	var current, frac strings.Builder
+	inFrac := false
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if inFrac {
+				frac.WriteByte(c)
+			} else {
+				current.WriteByte(c)
 			}
+			i++
+		case !inFrac && f.decimal != "" && strings.HasPrefix(s[i:], f.decimal):
+			inFrac = true
+			i += len(f.decimal)
+		case !inFrac && f.groupAt(s[i:]) > 0:
+			groups = append(groups, current.String())
+			current.Reset()
+			i += f.groupAt(s[i:])
+		default:
+			return "", false
 		}
 	}
+	groups = append(groups, current.String())
 
-	// generate sign part
-	var signStr string
-	if n >= 0.000000001 {
-		signStr = positiveStr
-	} else if n <= -0.000000001 {
-		signStr = negativeStr
-		n = -n
-	} else {
-		signStr = ""
-		n = 0.0
+	if len(groups) > 1 {
+		if f.groupSize <= 0 {
+			return "", false
+		}
+		size := f.secondaryGroup
+		if size <= 0 {
+			size = f.groupSize
+		}
+		last := len(groups) - 1
+		if len(groups[0]) < 1 || len(groups[0]) > size || len(groups[last]) != f.groupSize {
+			return "", false
+		}
+		for _, g := range groups[1:last] {
+			if len(g) != size {
+				return "", false
+			}
+		}
+	}
+
+	intPart := strings.Join(groups, "")
+	if intPart == "" && frac.Len() == 0 {
+		return "", false
+	}
+	if intPart == "" {
+		intPart = "0"
 	}
+	if frac.Len() > 0 {
+		return intPart + "." + frac.String(), true
+	}
+	return intPart, !inFrac
+}
 
-	// split number into integer and fractional parts
-	intf, fracf := math.Modf(n + renderFloatPrecisionRounders[precision])
+// parse reads a number written with the format and returns it as a
+// plain decimal number.
+func (f *NumberFormat) parse(s string) (string, error) {
+	in := strings.TrimSpace(s)
+	if f.pad != "" {
+		switch f.padAt {
+		case padBeforePrefix:
+			in = strings.TrimLeft(in, f.pad)
+		case padAfterSuffix:
+			in = strings.TrimRight(in, f.pad)
+		}
+	}
 
-	// generate integer part string
-	intStr := strconv.FormatInt(int64(intf), 10)
+	candidates := []struct {
+		prefix, suffix string
+		neg            bool
+	}{
+		{affix(f.negPrefix, -1), affix(f.negSuffix, -1), true},
+		{affix(f.prefix, 1), affix(f.suffix, 1), false},
+		{affix(f.prefix, 0), affix(f.suffix, 0), false},
+	}
+	for _, c := range candidates {
+		if !strings.HasPrefix(in, c.prefix) || !strings.HasSuffix(in, c.suffix) ||
+			len(in) < len(c.prefix)+len(c.suffix) {
+			continue
+		}
+		body := in[len(c.prefix) : len(in)-len(c.suffix)]
+		if f.pad != "" {
+			switch f.padAt {
+			case padAfterPrefix:
+				body = strings.TrimLeft(body, f.pad)
+			case padBeforeSuffix:
+				body = strings.TrimRight(body, f.pad)
+			}
+		}
 
-	// add thousand separator if required
-	if len(thousandStr) > 0 {
-		for i := len(intStr); i > 3; {
-			i -= 3
-			intStr = intStr[:i] + thousandStr + intStr[i:]
+		exp := 0
+		if f.exponent {
+			i := strings.LastIndexByte(body, 'E')
+			if i < 0 {
+				continue
+			}
+			e, err := strconv.Atoi(body[i+1:])
+			if err != nil || strings.HasPrefix(body[i+1:], "+") && !f.expPlus {
+				continue
+			}
+			body, exp = body[:i], e
+		}
+		digits, ok := f.parseDigits(body)
+		if !ok {
+			continue
 		}
+		digits = movePoint(digits, exp-f.shift)
+		if c.neg && digits != "0" {
+			digits = "-" + digits
+		}
+		return digits, nil
 	}
+	return "", fmt.Errorf("invalid number: %q", s)
+}
 
-	// no fractional part, we can leave now
-	if precision == 0 {
-		return signStr + intStr
+// ParseFloat parses a number written with the format.  Group
+// separators must be where the format puts them, though they may be
+// left out.  "NaN", "Infinity" and "-Infinity" are also accepted.
+//
+// e.g. the format "#,##0.00" parses "-1,234.5" as -1234.5, and
+// rejects "1,23,4.5"
+func (f *NumberFormat) ParseFloat(s string) (float64, error) {
+	switch strings.TrimSpace(s) {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	d, err := f.parse(s)
+	if err != nil {
+		return 0, err
 	}
+	return strconv.ParseFloat(d, 64)
+}
 
-	// generate fractional part
-	fracStr := strconv.Itoa(int(fracf * renderFloatPrecisionMultipliers[precision]))
-	// may need padding
-	if len(fracStr) < precision {
-		fracStr = "000000000000000"[:precision-len(fracStr)] + fracStr
+// ParseInt works like ParseFloat for whole numbers that fit in an
+// int64.
+func (f *NumberFormat) ParseInt(s string) (int64, error) {
+	d, err := f.parse(s)
+	if err != nil {
+		return 0, err
+	}
+	if strings.ContainsRune(d, '.') {
+		return 0, fmt.Errorf("not a whole number: %q", s)
 	}
+	return strconv.ParseInt(d, 10, 64)
+}
 
-	return signStr + intStr + decimalStr + fracStr
+// ParseBigInt works like ParseFloat for whole numbers of any size.
+func (f *NumberFormat) ParseBigInt(s string) (*big.Int, error) {
+	d, err := f.parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsRune(d, '.') {
+		return nil, fmt.Errorf("not a whole number: %q", s)
+	}
+	n, _ := new(big.Int).SetString(d, 10)
+	return n, nil
 }
 
-// FormatInteger produces a formatted number as string.
-// See FormatFloat.
-func FormatInteger(format string, n int) string {
-	return FormatFloat(format, float64(n))
+// ParseBigFloat works like ParseFloat, returning a big float precise
+// enough to hold every digit of the number.
+func (f *NumberFormat) ParseBigFloat(s string) (*big.Float, error) {
+	d, err := f.parse(s)
+	if err != nil {
+		return nil, err
+	}
+	// Each decimal digit needs less than 4 bits.
+	prec := uint(4 * len(d))
+	if prec < 64 {
+		prec = 64
+	}
+	n, _, err := big.ParseFloat(d, 10, prec, big.ToNearestEven)
+	return n, err
+}
+
+// ParseFormatted parses a number written with the given pattern, as
+// CompileNumberFormat reads it.
+//
+// e.g. ParseFormatted("(1,234.50)", "#,##0.00;(#,##0.00)") -> -1234.5, nil
+// e.g. ParseFormatted("12.5%", "0.0%") -> 0.125, nil
+func ParseFormatted(s, pattern string) (float64, error) {
+	f, err := CompileNumberFormat(pattern)
+	if err != nil {
+		return 0, err
+	}
+	return f.ParseFloat(s)
+}
+
+// ParseFormattedLocale parses a number written with the separators of
+// the given locale, grouped in threes.
+//
+// e.g. ParseFormattedLocale("1.234.567,89", NumberGerman) -> 1234567.89, nil
+// e.g. ParseFormattedLocale("12 345,6", NumberFrench) -> 12345.6, nil
+func ParseFormattedLocale(s string, locale NumberLocale) (float64, error) {
+	return groupedNumberFormat.WithSeparators(locale.Decimal, locale.Group).ParseFloat(s)
+}
+
+// groupedNumberFormat parses numbers grouped in threes.
+var groupedNumberFormat = &NumberFormat{
+	negPrefix: string(minusMark),
+	minInt:    1,
+	groupSize: 3,
+	decimal:   ".",
+	group:     ",",
 }
@@ -2,6 +2,7 @@ package humanize
 
 import (
 	"math"
+	"math/big"
 	"testing"
 )
 
@@ -77,3 +78,302 @@ func TestFormatFloat(t *testing.T) {
 	}
 
 }
+
+func TestCompileNumberFormat(t *testing.T) {
+	format := func(pattern string, n float64) string {
+		f, err := CompileNumberFormat(pattern)
+		if err != nil {
+			return err.Error()
+		}
+		return f.FormatFloat(n)
+	}
+	testList{
+		{"grouped", format("#,##0.00", 1234.5), "1,234.50"},
+		{"optional fraction", format("#,##0.0#", 1234.5), "1,234.5"},
+		{"optional fraction full", format("#,##0.0#", 1234.567), "1,234.57"},
+		{"no leading zero", format("#.##", 0.5), ".5"},
+		{"leading zero", format("0.##", 0.5), "0.5"},
+		{"zero", format("#", 0), "0"},
+		{"min int", format("000", 7), "007"},
+		{"half even", format("0", 2.5), "2"},
+		{"negative", format("#,##0.00", -1234.5), "-1,234.50"},
+		{"negative rounds to zero", format("0.0", -0.01), "-0.0"},
+		{"indian", format("#,##,##0", 1234567), "12,34,567"},
+		{"group of four", format("#,####", 123456789), "1,2345,6789"},
+		{"many digits", format("0.000000000000", 1.0/3), "0.333333333333"},
+		{"plus", format("+0.0 'dB'", 3), "+3.0 dB"},
+		{"plus negative", format("+0.0 'dB'", -3), "-3.0 dB"},
+		{"plus zero", format("+0", 0), "0"},
+		{"minus after", format("0-", -3), "3-"},
+		{"minus after positive", format("0-", 3), "3"},
+		{"prefix", format("$#,##0.00", -1234.5), "-$1,234.50"},
+		{"quoted", format("'#'0", 5), "#5"},
+		{"quote", format("0 o''clock", 5), "5 o'clock"},
+		{"nan", format("0.00", math.NaN()), "NaN"},
+		{"inf", format("0.00", math.Inf(-1)), "-Infinity"},
+	}.validate(t)
+}
+
+func TestNumberFormatOptions(t *testing.T) {
+	f, err := CompileNumberFormat("#,##0.00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testList{
+		{"separators", f.WithSeparators(",", ".").FormatFloat(1234.5), "1.234,50"},
+		{"thin space", f.WithSeparators(",", "\u202f").FormatFloat(1234.5), "1\u202f234,50"},
+		{"half up", f.WithRounding(RoundHalfUp).FormatFloat(0.125), "0.13"},
+		{"toward zero", f.WithRounding(RoundTowardZero).FormatFloat(-0.129), "-0.12"},
+		{"unchanged", f.FormatFloat(0.125), "0.12"},
+	}.validate(t)
+}
+
+func TestCompileNumberFormatErrors(t *testing.T) {
+	for _, bad := range []string{"", "abc", "0#", "0.#0", "0.0.0", "0.0,0", ",##0", "#,,##0", "#,", "1.00", "0 '", "0 x 0"} {
+		if f, err := CompileNumberFormat(bad); err == nil {
+			t.Errorf("Expected error compiling %q, got %+v", bad, f)
+		}
+	}
+}
+
+func TestNumberPatterns(t *testing.T) {
+	format := func(pattern string, n float64) string {
+		f, err := CompileNumberFormat(pattern)
+		if err != nil {
+			return err.Error()
+		}
+		return f.FormatFloat(n)
+	}
+	testList{
+		{"percent", format("0.0%", 0.256), "25.6%"},
+		{"percent exact", format("#,##0%", 12.3456), "1,235%"},
+		{"percent negative", format("0%", -0.5), "-50%"},
+		{"per mille", format("0‰", 0.0125), "12‰"},
+		{"quoted percent", format("0'%'", 5), "5%"},
+		{"scientific", format("0.00E+00", 1234.5), "1.23E+03"},
+		{"scientific small", format("0.00E+00", 0.00012345), "1.23E-04"},
+		{"scientific carry", format("0.00E0", 9.999), "1.00E1"},
+		{"scientific zero", format("0.0E0", 0), "0.0E0"},
+		{"scientific negative", format("0.###E0", -123456), "-1.235E5"},
+		{"scientific two digits", format("00.0E0", 12345), "12.3E3"},
+		{"engineering", format("##0.##E0", 12345), "12.3E3"},
+		{"engineering small", format("##0.##E0", 0.00012345), "123E-6"},
+		{"engineering one", format("##0.##E0", 1), "1E0"},
+		{"pad before prefix", format("*x$#,##0.00", 123), "xx$123.00"},
+		{"pad after prefix", format("$*x#,##0.00", 123), "$xx123.00"},
+		{"pad full", format("$*x#,##0.00", 1234), "$1,234.00"},
+		{"pad before suffix", format("#,##0*_ kg", 5), "5____ kg"},
+		{"pad after suffix", format("#,##0 kg*_", 5), "5 kg____"},
+		{"pad negative", format("* ##0", -5), " -5"},
+		{"negative pattern", format("#,##0.00;(#,##0.00)", -1234.5), "(1,234.50)"},
+		{"negative pattern positive", format("#,##0.00;(#,##0.00)", 1234.5), "1,234.50"},
+		{"negative pattern sign", format("0;0-", -5), "5-"},
+		{"quoted semicolon", format("0' ; '", 5), "5 ; "},
+		{"excel quotes", format(`0.0" kg"`, 2.25), "2.2 kg"},
+		{"backslash", format(`\#0`, 5), "#5"},
+		{"literal E", format("0 EUR", 5), "5 EUR"},
+	}.validate(t)
+}
+
+func TestNumberPatternTypes(t *testing.T) {
+	f, err := CompileNumberFormat("#,##0.00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	huge, _ := new(big.Float).SetPrec(200).SetString("123456789012345678901234567.891")
+	e, err := CompileNumberFormat("0.000E0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testList{
+		{"int64", f.FormatInt(9007199254740993), "9,007,199,254,740,993.00"},
+		{"int64 min", f.FormatInt(math.MinInt64), "-9,223,372,036,854,775,808.00"},
+		{"big float", f.FormatBigFloat(huge), "123,456,789,012,345,678,901,234,567.89"},
+		{"big float negative", f.FormatBigFloat(new(big.Float).Neg(huge)), "-123,456,789,012,345,678,901,234,567.89"},
+		{"big float inf", f.FormatBigFloat(new(big.Float).SetInf(true)), "-Infinity"},
+		{"big float scientific", e.FormatBigFloat(huge), "1.235E26"},
+		{"int64 scientific", e.FormatInt(-1500), "-1.500E3"},
+	}.validate(t)
+}
+
+func TestNumberPatternErrors(t *testing.T) {
+	for _, bad := range []string{"0;0;0", "0;x", "*x0*y", "0*", "$*x$0", "0 kg*_ g", "0.0E0,0", "#,##0E0", "0%‰", "0;*x0", `0\`, `0"`, "0%;0‰"} {
+		if f, err := CompileNumberFormat(bad); err == nil {
+			t.Errorf("Expected error compiling %q, got %+v", bad, f)
+		}
+	}
+}
+
+func TestFormatIntegerExact(t *testing.T) {
+	big1e30, _ := new(big.Int).SetString("-1000000000000000000000000000001", 10)
+	testList{
+		{"int", FormatInteger("#,###.", 1234567), "1,234,567"},
+		{"int64 above 2^53", FormatInteger64("#,###.", 9007199254740993), "9,007,199,254,740,993"},
+		{"int64 default", FormatInteger64("", 9007199254740993), "9,007,199,254,740,993.00"},
+		{"int64 min", FormatInteger64("#.###,", math.MinInt64), "-9.223.372.036.854.775.808"},
+		{"int64 plus", FormatInteger64("+#,###.", 12345), "+12,345"},
+		{"int64 precision", FormatInteger64("#", 12345), "12345.000000000"},
+		{"uint64 max", FormatIntegerUint64("#,###.", math.MaxUint64), "18,446,744,073,709,551,615"},
+		{"uint64 zero", FormatIntegerUint64("+#,###.", 0), "0"},
+		{"big", BigFormatInteger("# ###,", big1e30), "-1 000 000 000 000 000 000 000 000 000 001"},
+		{"big zero", BigFormatInteger("", new(big.Int)), "0.00"},
+	}.validate(t)
+
+	f, err := CompileNumberFormat("#,##0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testList{
+		{"method uint64", f.FormatUint(math.MaxUint64), "18,446,744,073,709,551,615"},
+		{"method big", f.FormatBigInt(big1e30), "-1,000,000,000,000,000,000,000,000,000,001"},
+	}.validate(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected BigFormatInteger to panic on an invalid format")
+		}
+	}()
+	BigFormatInteger("-", big.NewInt(1))
+}
+
+func TestLegacyNumberFormatCache(t *testing.T) {
+	a := mustLegacyNumberFormat("#.###,##")
+	if b := mustLegacyNumberFormat("#.###,##"); a != b {
+		t.Errorf("Expected the compiled format to be reused, got %p and %p", a, b)
+	}
+	testList{
+		{"float", FormatFloat("#.###,##", 12345.6789), "12.345,68"},
+		{"int", FormatInteger("#.###,##", 12345), "12.345,00"},
+	}.validate(t)
+}
+
+func BenchmarkFormatFloat(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		FormatFloat("#,###.##", 12345.6789)
+	}
+}
+
+func TestParseFormatted(t *testing.T) {
+	tests := []struct {
+		in, pattern string
+		exp         float64
+	}{
+		{"1,234.50", "#,##0.00", 1234.5},
+		{"1234.5", "#,##0.00", 1234.5},
+		{"-1,234.50", "#,##0.00", -1234.5},
+		{"(1,234.50)", "#,##0.00;(#,##0.00)", -1234.5},
+		{"12,34,567", "#,##,##0", 1234567},
+		{"12.5%", "0.0%", 0.125},
+		{"125‰", "0‰", 0.125},
+		{"1.23E+03", "0.00E+00", 1230},
+		{"-1.5E-3", "0.0E0", -0.0015},
+		{"+3.0 dB", "+0.0 'dB'", 3},
+		{"-3.0 dB", "+0.0 'dB'", -3},
+		{"$xx123.00", "$*x#,##0.00", 123},
+		{"5 kg____", "#,##0 kg*_", 5},
+		{"5-", "0;0-", -5},
+		{"Infinity", "0", math.Inf(1)},
+	}
+	for _, test := range tests {
+		got, err := ParseFormatted(test.in, test.pattern)
+		if err != nil {
+			t.Errorf("Error parsing %q with %q: %v", test.in, test.pattern, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q with %q, got %v", test.exp, test.in, test.pattern, got)
+		}
+	}
+
+	bad := []struct{ in, pattern string }{
+		{"1,23,4.5", "#,##0.00"},
+		{"1,234,567", "#,##,##0"},
+		{"1,234", "0"},
+		{"12.5", "0.0%"},
+		{"$12", "0"},
+		{"5", "0'"},
+	}
+	for _, test := range bad {
+		if got, err := ParseFormatted(test.in, test.pattern); err == nil {
+			t.Errorf("Expected error parsing %q with %q, got %v", test.in, test.pattern, got)
+		}
+	}
+}
+
+func TestParseFormattedLocale(t *testing.T) {
+	tests := []struct {
+		in     string
+		locale NumberLocale
+		exp    float64
+	}{
+		{"1,234,567.89", NumberEnglish, 1234567.89},
+		{"1.234.567,89", NumberGerman, 1234567.89},
+		{"12 345,6", NumberFrench, 12345.6},
+		{"12\u00a0345,6", NumberFrench, 12345.6},
+		{"12\u202f345,6", NumberFrench, 12345.6},
+		{"1’234.5", NumberSwiss, 1234.5},
+		{"-0,5", NumberGerman, -0.5},
+	}
+	for _, test := range tests {
+		got, err := ParseFormattedLocale(test.in, test.locale)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"1.5", "1,234.567,8", "12.34.567"} {
+		if got, err := ParseFormattedLocale(bad, NumberGerman); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestNumberFormatParse(t *testing.T) {
+	f, err := CompileNumberFormat("#,##0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f = f.WithSeparators(",", ".")
+
+	if got, err := f.ParseInt("-9.223.372.036.854.775.808"); err != nil || got != math.MinInt64 {
+		t.Errorf("ParseInt = %v, %v", got, err)
+	}
+	if got, err := f.ParseInt("1,5"); err == nil {
+		t.Errorf("Expected error parsing a fraction, got %v", got)
+	}
+	if got, err := f.ParseInt("9.223.372.036.854.775.808"); err == nil {
+		t.Errorf("Expected error parsing an overflow, got %v", got)
+	}
+
+	huge := "1.000.000.000.000.000.000.000.000.000.001"
+	n, err := f.ParseBigInt(huge)
+	if err != nil || f.FormatBigInt(n) != huge {
+		t.Errorf("ParseBigInt(%q) = %v, %v", huge, n, err)
+	}
+
+	exact := "123.456.789.012.345.678.901.234.567,890123"
+	x, err := f.ParseBigFloat(exact)
+	if err != nil {
+		t.Fatalf("Error parsing %q: %v", exact, err)
+	}
+	if got := f.WithSeparators(".", ",").FormatBigFloat(x); got != "123,456,789,012,345,678,901,234,568" {
+		t.Errorf("ParseBigFloat(%q) formatted as %q", exact, got)
+	}
+	if got := x.Text('f', 6); got != "123456789012345678901234567.890123" {
+		t.Errorf("ParseBigFloat(%q) = %v", exact, got)
+	}
+
+	// Every number survives a round trip through its format.
+	for _, pattern := range []string{"#,##0.###", "0.00E+00", "#,##0.00;(#,##0.00)", "0.0%", "$*x#,##0.00"} {
+		p, err := CompileNumberFormat(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range []float64{0, 1.5, -1234.5, 0.25, 1e6} {
+			s := p.FormatFloat(n)
+			if got, err := p.ParseFloat(s); err != nil || math.Abs(got-n) > math.Abs(n)*0.01 {
+				t.Errorf("Round trip of %v through %q gave %v, %v", n, s, got, err)
+			}
+		}
+	}
+}
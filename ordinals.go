@@ -1,25 +1,197 @@
 package humanize
 
-import "strconv"
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GrammaticalGender selects between the ordinal forms of languages
+// that agree ordinals with the noun, such as French 1er and 1re.
+type GrammaticalGender int
+
+// Grammatical genders.
+const (
+	Masculine GrammaticalGender = iota
+	Feminine
+)
+
+// OrdinalLocale writes the ordinal suffixes of a language.
+type OrdinalLocale struct {
+	// Suffix returns the suffix for the number with the given
+	// decimal digits, without a sign.
+	Suffix func(digits string, gender GrammaticalGender) string
+	// Group separates groups of three digits when
+	// OrdinalOptions.Commas is set.
+	Group string
+}
+
+// OrdinalEnglish is the default OrdinalLocale: 1st, 2nd, 3rd, 4th.
+var OrdinalEnglish = OrdinalLocale{
+	Suffix: func(digits string, _ GrammaticalGender) string {
+		tens := 0
+		if len(digits) > 1 {
+			tens = int(digits[len(digits)-2] - '0')
+		}
+		if tens != 1 {
+			switch digits[len(digits)-1] {
+			case '1':
+				return "st"
+			case '2':
+				return "nd"
+			case '3':
+				return "rd"
+			}
+		}
+		return "th"
+	},
+	Group: ",",
+}
+
+// OrdinalFrench writes French ordinals: 1er, 1re, 2e.  Digits are
+// grouped with a narrow no-break space.
+var OrdinalFrench = OrdinalLocale{
+	Suffix: func(digits string, gender GrammaticalGender) string {
+		if digits != "1" {
+			return "e"
+		}
+		if gender == Feminine {
+			return "re"
+		}
+		return "er"
+	},
+	Group: " ",
+}
+
+// OrdinalSpanish writes Spanish ordinals: 1.º, 1.ª.
+var OrdinalSpanish = OrdinalLocale{
+	Suffix: func(_ string, gender GrammaticalGender) string {
+		if gender == Feminine {
+			return ".ª"
+		}
+		return ".º"
+	},
+	Group: ".",
+}
+
+// OrdinalOptions controls how OrdinalWithOptions writes an ordinal.
+// The zero value writes English ordinals like Ordinal.
+type OrdinalOptions struct {
+	// Commas groups the digits, as in "1,001st".
+	Commas bool
+	// Superscript writes the suffix with Unicode superscript
+	// letters, as in "1ˢᵗ".
+	Superscript bool
+	// Locale replaces OrdinalEnglish when not nil.
+	Locale *OrdinalLocale
+	// Gender is passed to the locale's Suffix.
+	Gender GrammaticalGender
+}
+
+var superscriptLetters = strings.NewReplacer(
+	"d", "ᵈ", "e", "ᵉ", "h", "ʰ", "n", "ⁿ", "r", "ʳ", "s", "ˢ", "t", "ᵗ",
+)
+
+// ordinal writes the signed decimal number s as an ordinal.
+func ordinal(s string, opts OrdinalOptions) string {
+	locale := opts.Locale
+	if locale == nil {
+		locale = &OrdinalEnglish
+	}
+	suffix := locale.Suffix(strings.TrimPrefix(s, "-"), opts.Gender)
+	if opts.Superscript {
+		suffix = superscriptLetters.Replace(suffix)
+	}
+	if opts.Commas {
+		s = strings.Replace(commaDecimal(s), ",", locale.Group, -1)
+	}
+	return s + suffix
+}
 
 // Ordinal gives you the input number in a rank/ordinal format.
 //
 // Ordinal(3) -> 3rd
 func Ordinal(x int) string {
-	suffix := "th"
-	switch x % 10 {
-	case 1:
-		if x%100 != 11 {
-			suffix = "st"
-		}
-	case 2:
-		if x%100 != 12 {
-			suffix = "nd"
-		}
-	case 3:
-		if x%100 != 13 {
-			suffix = "rd"
-		}
+	return ordinal(strconv.Itoa(x), OrdinalOptions{})
+}
+
+// Ordinal64 works like Ordinal for int64 values.
+func Ordinal64(x int64) string {
+	return ordinal(strconv.FormatInt(x, 10), OrdinalOptions{})
+}
+
+// OrdinalUint64 works like Ordinal for uint64 values.
+func OrdinalUint64(x uint64) string {
+	return ordinal(strconv.FormatUint(x, 10), OrdinalOptions{})
+}
+
+// BigOrdinal works like Ordinal for big integers.
+func BigOrdinal(x *big.Int) string {
+	return ordinal(x.String(), OrdinalOptions{})
+}
+
+// OrdinalWithOptions writes an ordinal as opts asks.
+//
+// e.g. OrdinalWithOptions(1001, OrdinalOptions{Commas: true}) -> 1,001st
+// e.g. OrdinalWithOptions(2, OrdinalOptions{Superscript: true}) -> 2ⁿᵈ
+// e.g. OrdinalWithOptions(1, OrdinalOptions{Locale: &OrdinalFrench, Gender: Feminine}) -> 1re
+func OrdinalWithOptions(x int64, opts OrdinalOptions) string {
+	return ordinal(strconv.FormatInt(x, 10), opts)
+}
+
+// BigOrdinalWithOptions works like OrdinalWithOptions for big
+// integers.
+func BigOrdinalWithOptions(x *big.Int, opts OrdinalOptions) string {
+	return ordinal(x.String(), opts)
+}
+
+var ordinalRegex = regexp.MustCompile(`^(?:the\s+)?(-?(?:[0-9]{1,3}(?:,[0-9]{3})+|[0-9]+))\s*([a-z]+)$`)
+
+var superscriptLettersReverse = strings.NewReplacer(
+	"ᵈ", "d", "ᵉ", "e", "ʰ", "h", "ⁿ", "n", "ʳ", "r", "ˢ", "s", "ᵗ", "t",
+)
+
+// parseOrdinal splits an English ordinal into its digits, checking the
+// suffix against them.
+func parseOrdinal(s string) (string, error) {
+	in := superscriptLettersReverse.Replace(strings.ToLower(strings.TrimSpace(s)))
+	found := ordinalRegex.FindStringSubmatch(in)
+	if found == nil {
+		return "", fmt.Errorf("invalid ordinal: %q", s)
+	}
+	digits := strings.Replace(found[1], ",", "", -1)
+	if want := OrdinalEnglish.Suffix(strings.TrimPrefix(digits, "-"), Masculine); found[2] != want {
+		return "", fmt.Errorf("ordinal %q should end in %q", s, want)
+	}
+	return digits, nil
+}
+
+// ParseOrdinal parses an English ordinal such as "22nd" or "1,001st",
+// as Ordinal and OrdinalWithOptions write them.  A leading "the" is
+// allowed, and a suffix that does not match the number, as in "2st",
+// is an error.
+//
+// e.g. ParseOrdinal("113th") -> 113, nil
+// e.g. ParseOrdinal("the 3rd") -> 3, nil
+func ParseOrdinal(s string) (int64, error) {
+	digits, err := parseOrdinal(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+// ParseBigOrdinal works like ParseOrdinal, but returns a big integer.
+func ParseBigOrdinal(s string) (*big.Int, error) {
+	digits, err := parseOrdinal(s)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, errInvalid
 	}
-	return strconv.Itoa(x) + suffix
+	return n, nil
 }
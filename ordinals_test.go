@@ -1,6 +1,8 @@
 package humanize
 
 import (
+	"math"
+	"math/big"
 	"testing"
 )
 
@@ -26,3 +28,83 @@ func TestOrdinals(t *testing.T) {
 		{"213", Ordinal(213), "213th"},
 	}.validate(t)
 }
+
+func TestOrdinalVariants(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890112", 10)
+	testList{
+		{"negative", Ordinal(-1), "-1st"},
+		{"negative 11", Ordinal(-11), "-11th"},
+		{"int64", Ordinal64(math.MaxInt64), "9223372036854775807th"},
+		{"int64 min", Ordinal64(math.MinInt64), "-9223372036854775808th"},
+		{"uint64", OrdinalUint64(math.MaxUint64), "18446744073709551615th"},
+		{"uint64 22", OrdinalUint64(22), "22nd"},
+		{"big", BigOrdinal(huge), "123456789012345678901234567890112th"},
+		{"big 1", BigOrdinal(big.NewInt(21)), "21st"},
+	}.validate(t)
+}
+
+func TestOrdinalWithOptions(t *testing.T) {
+	testList{
+		{"zero", OrdinalWithOptions(3, OrdinalOptions{}), "3rd"},
+		{"commas", OrdinalWithOptions(1001, OrdinalOptions{Commas: true}), "1,001st"},
+		{"commas negative", OrdinalWithOptions(-1234567, OrdinalOptions{Commas: true}), "-1,234,567th"},
+		{"superscript", OrdinalWithOptions(2, OrdinalOptions{Superscript: true}), "2ⁿᵈ"},
+		{"superscript th", OrdinalWithOptions(1000, OrdinalOptions{Superscript: true, Commas: true}), "1,000ᵗʰ"},
+		{"french", OrdinalWithOptions(1, OrdinalOptions{Locale: &OrdinalFrench}), "1er"},
+		{"french feminine", OrdinalWithOptions(1, OrdinalOptions{Locale: &OrdinalFrench, Gender: Feminine}), "1re"},
+		{"french 2", OrdinalWithOptions(2, OrdinalOptions{Locale: &OrdinalFrench, Gender: Feminine}), "2e"},
+		{"french 21", OrdinalWithOptions(21, OrdinalOptions{Locale: &OrdinalFrench}), "21e"},
+		{"french superscript", OrdinalWithOptions(1, OrdinalOptions{Locale: &OrdinalFrench, Superscript: true}), "1ᵉʳ"},
+		{"french group", OrdinalWithOptions(1000, OrdinalOptions{Locale: &OrdinalFrench, Commas: true}), "1\u202f000e"},
+		{"spanish", OrdinalWithOptions(1, OrdinalOptions{Locale: &OrdinalSpanish}), "1.º"},
+		{"spanish feminine", OrdinalWithOptions(3, OrdinalOptions{Locale: &OrdinalSpanish, Gender: Feminine}), "3.ª"},
+		{"big options", BigOrdinalWithOptions(big.NewInt(1000002), OrdinalOptions{Commas: true}), "1,000,002nd"},
+	}.validate(t)
+}
+
+func TestParseOrdinal(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp int64
+	}{
+		{"1st", 1},
+		{"22nd", 22},
+		{"113th", 113},
+		{"11th", 11},
+		{"the 3rd", 3},
+		{"The 21ST", 21},
+		{"1,001st", 1001},
+		{"1001st", 1001},
+		{"-2nd", -2},
+		{"0th", 0},
+		{"2ⁿᵈ", 2},
+		{" 4 th ", 4},
+		{"9,223,372,036,854,775,807th", math.MaxInt64},
+	}
+	for _, test := range tests {
+		got, err := ParseOrdinal(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", "1", "st", "2st", "11st", "12nd", "3th", "1,00st", "10,01st", "1st place", "9223372036854775808th", "1.5th"} {
+		if got, err := ParseOrdinal(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestParseBigOrdinal(t *testing.T) {
+	for _, n := range []string{"0", "1", "12", "123456789012345678901234567890112", "-1000003"} {
+		x, _ := new(big.Int).SetString(n, 10)
+		for _, s := range []string{BigOrdinal(x), BigOrdinalWithOptions(x, OrdinalOptions{Commas: true, Superscript: true})} {
+			got, err := ParseBigOrdinal(s)
+			if err != nil || got.Cmp(x) != 0 {
+				t.Errorf("ParseBigOrdinal(%q) = %v, %v; want %v", s, got, err, x)
+			}
+		}
+	}
+}
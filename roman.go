@@ -0,0 +1,146 @@
+package humanize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var romanNumerals = []struct {
+	value   int
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+var romanValues = map[rune]int{
+	'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000,
+}
+
+// romanGlyphs are the Unicode number forms for one to twelve, upper
+// case from U+2160 and lower case from U+2170.
+var romanGlyphs = []string{
+	"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X", "XI", "XII",
+	"L", "C", "D", "M",
+}
+
+var romanToGlyphs, romanFromGlyphs *strings.Replacer
+
+func init() {
+	var to, from []string
+	for i, s := range romanGlyphs {
+		upper, lower := string(rune(0x2160+i)), string(rune(0x2170+i))
+		if len(s) == 1 {
+			to = append(to, s, upper, strings.ToLower(s), lower)
+		}
+		from = append(from, upper, s, lower, strings.ToLower(s))
+	}
+	romanToGlyphs = strings.NewReplacer(to...)
+	romanFromGlyphs = strings.NewReplacer(from...)
+}
+
+// RomanOptions controls how RomanWithOptions writes and
+// ParseRomanWithOptions reads roman numerals.
+type RomanOptions struct {
+	// Lowercase writes "xiv" rather than "XIV".
+	Lowercase bool
+	// Unicode writes the roman numeral glyphs of the Unicode number
+	// forms block, as a single glyph from one to twelve ("Ⅻ").
+	Unicode bool
+	// Strict makes ParseRomanWithOptions accept only numerals in
+	// their canonical form, such as "IV" but not "IIII", in a
+	// single case.
+	Strict bool
+}
+
+// Roman writes a number from 1 to 3999 in upper case roman numerals.
+// Other numbers are written in decimal digits.
+//
+// e.g. Roman(14) -> XIV
+// e.g. Roman(2024) -> MMXXIV
+func Roman(n int) string {
+	return RomanWithOptions(n, RomanOptions{})
+}
+
+// RomanWithOptions writes a number in roman numerals as opts asks.
+//
+// e.g. RomanWithOptions(4, RomanOptions{Lowercase: true}) -> iv
+// e.g. RomanWithOptions(12, RomanOptions{Unicode: true}) -> Ⅻ
+func RomanWithOptions(n int, opts RomanOptions) string {
+	if n < 1 || n > 3999 {
+		return strconv.Itoa(n)
+	}
+	if opts.Unicode && n <= 12 {
+		r := rune(0x2160 + n - 1)
+		if opts.Lowercase {
+			r += 0x10
+		}
+		return string(r)
+	}
+
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for ; n >= r.value; n -= r.value {
+			b.WriteString(r.numeral)
+		}
+	}
+	s := b.String()
+	if opts.Lowercase {
+		s = strings.ToLower(s)
+	}
+	if opts.Unicode {
+		s = romanToGlyphs.Replace(s)
+	}
+	return s
+}
+
+// ParseRoman parses roman numerals in either case, and in Unicode
+// glyphs, leniently: any numeral is added, unless a larger one follows
+// it, so "IIII" is 4.
+//
+// See also: ParseRomanWithOptions.
+//
+// e.g. ParseRoman("xiv") -> 14, nil
+func ParseRoman(s string) (int, error) {
+	return ParseRomanWithOptions(s, RomanOptions{})
+}
+
+// ParseRomanWithOptions parses roman numerals as ParseRoman does, or
+// only canonical ones if opts.Strict is set.  Lowercase and Unicode
+// are ignored.
+//
+// e.g. ParseRomanWithOptions("IIII", RomanOptions{Strict: true}) -> 0, error
+func ParseRomanWithOptions(s string, opts RomanOptions) (int, error) {
+	in := strings.TrimSpace(s)
+	ascii := romanFromGlyphs.Replace(in)
+	upper := strings.ToUpper(ascii)
+	if upper == "" {
+		return 0, errInvalid
+	}
+
+	n := 0
+	for i, r := range upper {
+		v, ok := romanValues[r]
+		if !ok {
+			return 0, fmt.Errorf("invalid roman numeral: %q", s)
+		}
+		if i+1 < len(upper) && v < romanValues[rune(upper[i+1])] {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("invalid roman numeral: %q", s)
+	}
+
+	if opts.Strict {
+		canonical := Roman(n)
+		if upper != canonical || (ascii != canonical && ascii != strings.ToLower(canonical)) {
+			return 0, fmt.Errorf("roman numeral %q is not canonical, want %q", s, canonical)
+		}
+	}
+	return n, nil
+}
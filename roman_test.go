@@ -0,0 +1,83 @@
+package humanize
+
+import (
+	"testing"
+)
+
+func TestRoman(t *testing.T) {
+	testList{
+		{"1", Roman(1), "I"},
+		{"4", Roman(4), "IV"},
+		{"9", Roman(9), "IX"},
+		{"14", Roman(14), "XIV"},
+		{"40", Roman(40), "XL"},
+		{"90", Roman(90), "XC"},
+		{"400", Roman(400), "CD"},
+		{"1994", Roman(1994), "MCMXCIV"},
+		{"2024", Roman(2024), "MMXXIV"},
+		{"3999", Roman(3999), "MMMCMXCIX"},
+		{"0", Roman(0), "0"},
+		{"4000", Roman(4000), "4000"},
+		{"-5", Roman(-5), "-5"},
+	}.validate(t)
+}
+
+func TestRomanWithOptions(t *testing.T) {
+	testList{
+		{"lower", RomanWithOptions(14, RomanOptions{Lowercase: true}), "xiv"},
+		{"unicode 12", RomanWithOptions(12, RomanOptions{Unicode: true}), "Ⅻ"},
+		{"unicode 4 lower", RomanWithOptions(4, RomanOptions{Unicode: true, Lowercase: true}), "ⅳ"},
+		{"unicode 14", RomanWithOptions(14, RomanOptions{Unicode: true}), "ⅩⅠⅤ"},
+		{"unicode 1666 lower", RomanWithOptions(1666, RomanOptions{Unicode: true, Lowercase: true}), "ⅿⅾⅽⅼⅹⅴⅰ"},
+		{"unicode out of range", RomanWithOptions(0, RomanOptions{Unicode: true}), "0"},
+	}.validate(t)
+}
+
+func TestParseRoman(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp int
+	}{
+		{"XIV", 14},
+		{"xiv", 14},
+		{" MCMXCIV ", 1994},
+		{"IIII", 4},
+		{"IM", 999},
+		{"MMMM", 4000},
+		{"Ⅻ", 12},
+		{"ⅿⅾⅽⅼⅹⅵ", 1666},
+		{"ⅩⅠⅤ", 14},
+	}
+	for _, test := range tests {
+		got, err := ParseRoman(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", "XIVA", "14", "IVI X"} {
+		if got, err := ParseRoman(bad); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestParseRomanStrict(t *testing.T) {
+	strict := RomanOptions{Strict: true}
+	for n := 1; n <= 3999; n++ {
+		for _, opts := range []RomanOptions{{}, {Lowercase: true}, {Unicode: true}, {Unicode: true, Lowercase: true}} {
+			s := RomanWithOptions(n, opts)
+			if got, err := ParseRomanWithOptions(s, strict); err != nil || got != n {
+				t.Fatalf("ParseRomanWithOptions(%q) = %v, %v; want %v", s, got, err, n)
+			}
+		}
+	}
+
+	for _, bad := range []string{"IIII", "IM", "VX", "XXXXX", "MMMM", "Xiv", "IIV"} {
+		if got, err := ParseRomanWithOptions(bad, strict); err == nil {
+			t.Errorf("Expected error parsing %q strictly, got %v", bad, got)
+		}
+	}
+}
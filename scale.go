@@ -0,0 +1,224 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A NumberScale is a system of names for large powers of ten.
+type NumberScale int
+
+const (
+	// ShortScale names each power of a thousand: a billion is 10^9,
+	// as in modern English.
+	ShortScale NumberScale = iota
+	// LongScale names each power of a million, with the -iard
+	// names in between: a billion is 10^12 and a milliard 10^9.
+	LongScale
+)
+
+type scaleName struct {
+	exponent int
+	name     string
+}
+
+var shortScaleNames = []scaleName{
+	{3, "thousand"},
+	{6, "million"},
+	{9, "billion"},
+	{12, "trillion"},
+	{15, "quadrillion"},
+	{18, "quintillion"},
+	{21, "sextillion"},
+	{24, "septillion"},
+	{27, "octillion"},
+	{30, "nonillion"},
+	{33, "decillion"},
+}
+
+var longScaleNames = []scaleName{
+	{3, "thousand"},
+	{6, "million"},
+	{9, "milliard"},
+	{12, "billion"},
+	{15, "billiard"},
+	{18, "trillion"},
+	{21, "trilliard"},
+	{24, "quadrillion"},
+	{27, "quadrilliard"},
+	{30, "quintillion"},
+	{33, "quintilliard"},
+}
+
+func (scale NumberScale) names() []scaleName {
+	if scale == LongScale {
+		return longScaleNames
+	}
+	return shortScaleNames
+}
+
+// ScaleOptions controls how ScaleWordsWithOptions formats a value.
+// The zero value uses the short scale and keeps all the digits of the
+// value, like SIOptions.
+type ScaleOptions struct {
+	Scale NumberScale
+
+	// Precision, Digits and Rounding round the value as in
+	// SIOptions.
+	Precision SIPrecision
+	Digits    int
+	Rounding  RoundingMode
+
+	// Thousands also names thousands ("12.3 thousand") instead of
+	// writing them with commas ("12,300").
+	Thousands bool
+}
+
+// defaultScaleOptions are used by ScaleWords, ScaleWordsf and
+// BigScaleWords.
+var defaultScaleOptions = ScaleOptions{Precision: SIDecimals, Digits: 1}
+
+// scaleDecimal divides the plain decimal number s by the largest of
+// the powers of ten in exponents (ascending, starting with 0) it is at
+// least one of, and rounds the result.  It returns the result and the
+// index of the exponent used, which is the next one if rounding
+// carried into it, so that 999999.96 is never "1000 thousand".
+func scaleDecimal(s string, exponents []int, precision SIPrecision, digits int, mode RoundingMode) (string, int) {
+	mag := decimalMagnitude(s)
+	i := 0
+	for j, e := range exponents {
+		if mag > e {
+			i = j
+		}
+	}
+	v := roundPrecision(movePoint(s, -exponents[i]), precision, digits, mode)
+	if i+1 < len(exponents) && decimalMagnitude(v) > exponents[i+1]-exponents[i] {
+		i++
+		v = roundPrecision(movePoint(s, -exponents[i]), precision, digits, mode)
+	}
+	return v, i
+}
+
+// scaleWords formats the plain decimal number s with a scale name.
+func scaleWords(s string, opts ScaleOptions) string {
+	names := []scaleName{{0, ""}}
+	for _, n := range opts.Scale.names() {
+		if n.exponent > 3 || opts.Thousands {
+			names = append(names, n)
+		}
+	}
+
+	exponents := make([]int, len(names))
+	for i, n := range names {
+		exponents[i] = n.exponent
+	}
+	v, i := scaleDecimal(s, exponents, opts.Precision, opts.Digits, opts.Rounding)
+
+	v = commaDecimal(v)
+	if names[i].name == "" {
+		return v
+	}
+	return v + " " + names[i].name
+}
+
+// ScaleWords writes a large number with a short scale name and one
+// decimal place.  Numbers below a million are written with commas.
+//
+// See also: ScaleWordsWithOptions, ParseScaleWords.
+//
+// e.g. ScaleWords(1234567) -> 1.2 million
+// e.g. ScaleWords(3000000000) -> 3 billion
+// e.g. ScaleWords(12345) -> 12,345
+func ScaleWords(n int64) string {
+	return scaleWords(strconv.FormatInt(n, 10), defaultScaleOptions)
+}
+
+// ScaleWordsf works like ScaleWords for floats.
+//
+// e.g. ScaleWordsf(4.5e13) -> 45 trillion
+func ScaleWordsf(f float64) string {
+	return ScaleWordsWithOptions(f, defaultScaleOptions)
+}
+
+// BigScaleWords works like ScaleWords for big integers.
+func BigScaleWords(n *big.Int) string {
+	return BigScaleWordsWithOptions(n, defaultScaleOptions)
+}
+
+// ScaleWordsWithOptions writes a large number with a scale name,
+// rounded as opts asks.
+//
+// e.g. ScaleWordsWithOptions(1234567, ScaleOptions{Precision: SISignificant, Digits: 3}) -> 1.23 million
+// e.g. ScaleWordsWithOptions(2.5e9, ScaleOptions{Scale: LongScale}) -> 2.5 milliard
+// e.g. ScaleWordsWithOptions(999999.96, ScaleOptions{Precision: SIDecimals, Digits: 1}) -> 1 million
+func ScaleWordsWithOptions(f float64, opts ScaleOptions) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return s
+	}
+	return scaleWords(s, opts)
+}
+
+// BigScaleWordsWithOptions works like ScaleWordsWithOptions for big
+// integers.
+func BigScaleWordsWithOptions(n *big.Int, opts ScaleOptions) string {
+	return scaleWords(n.String(), opts)
+}
+
+var scaleWordsRegex = regexp.MustCompile(`^([+\-]?[0-9][0-9,]*(?:\.[0-9]+)?|[+\-]?\.[0-9]+)\s*([a-zA-Z]*)$`)
+
+// parseScaleWords splits s into a plain decimal number and the power
+// of ten its scale name stands for.
+func parseScaleWords(s string, scale NumberScale) (string, int, error) {
+	found := scaleWordsRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if found == nil {
+		return "", 0, errInvalid
+	}
+	num := strings.TrimPrefix(strings.Replace(found[1], ",", "", -1), "+")
+	name := strings.ToLower(found[2])
+	if name == "" {
+		return num, 0, nil
+	}
+	for _, n := range scale.names() {
+		if n.name == name {
+			return num, n.exponent, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unhandled scale name: %q", found[2])
+}
+
+// ParseScaleWords parses a number written with a scale name, such as
+// "1.2 million", as ScaleWords writes it.  The names are read in the
+// given scale.
+//
+// e.g. ParseScaleWords("3.4 billion", ShortScale) -> 3.4e9, nil
+// e.g. ParseScaleWords("3.4 billion", LongScale) -> 3.4e12, nil
+func ParseScaleWords(s string, scale NumberScale) (float64, error) {
+	num, exp, err := parseScaleWords(s, scale)
+	if err != nil {
+		return 0, err
+	}
+	return parseSINumber(num, exp)
+}
+
+// ParseBigScaleWords works like ParseScaleWords, but returns a big
+// integer.  Numbers that are not whole, such as "1.2345 thousand",
+// are an error.
+func ParseBigScaleWords(s string, scale NumberScale) (*big.Int, error) {
+	num, exp, err := parseScaleWords(s, scale)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(movePoint(num, exp))
+	if !ok {
+		return nil, errInvalid
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("not a whole number: %v", s)
+	}
+	return r.Num(), nil
+}
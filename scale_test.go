@@ -0,0 +1,89 @@
+package humanize
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestScaleWords(t *testing.T) {
+	huge, _ := new(big.Int).SetString("45000000000000000000000000000000000000", 10)
+	testList{
+		{"million", ScaleWords(1234567), "1.2 million"},
+		{"billion", ScaleWords(3000000000), "3 billion"},
+		{"small", ScaleWords(12345), "12,345"},
+		{"zero", ScaleWords(0), "0"},
+		{"negative", ScaleWords(-2500000), "-2.5 million"},
+		{"carry", ScaleWordsf(999999.96), "1 million"},
+		{"carry name", ScaleWords(999960000), "1 billion"},
+		{"trillion", ScaleWordsf(4.5e13), "45 trillion"},
+		{"float small", ScaleWordsf(1234.56), "1,234.6"},
+		{"big", BigScaleWords(big.NewInt(7e15)), "7 quadrillion"},
+		{"beyond names", BigScaleWords(huge), "45,000 decillion"},
+	}.validate(t)
+}
+
+func TestScaleWordsWithOptions(t *testing.T) {
+	testList{
+		{"shortest", ScaleWordsWithOptions(1234567, ScaleOptions{}), "1.234567 million"},
+		{"significant", ScaleWordsWithOptions(1234567, ScaleOptions{Precision: SISignificant, Digits: 3}), "1.23 million"},
+		{"fixed", ScaleWordsWithOptions(3e9, ScaleOptions{Precision: SIFixed, Digits: 2}), "3.00 billion"},
+		{"round up", ScaleWordsWithOptions(1250000, ScaleOptions{Precision: SIDecimals, Digits: 1, Rounding: RoundHalfUp}), "1.3 million"},
+		{"thousands", ScaleWordsWithOptions(12300, ScaleOptions{Thousands: true}), "12.3 thousand"},
+		{"long milliard", ScaleWordsWithOptions(2.5e9, ScaleOptions{Scale: LongScale}), "2.5 milliard"},
+		{"long billion", ScaleWordsWithOptions(4e12, ScaleOptions{Scale: LongScale}), "4 billion"},
+		{"inf", ScaleWordsf(math.Inf(1)), "+Inf"},
+		{"big options", BigScaleWordsWithOptions(big.NewInt(1234567890), ScaleOptions{Precision: SIDecimals, Digits: 2}), "1.23 billion"},
+	}.validate(t)
+}
+
+func TestParseScaleWords(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale NumberScale
+		exp   float64
+	}{
+		{"1.2 million", ShortScale, 1.2e6},
+		{"3 billion", ShortScale, 3e9},
+		{"45 Trillion", ShortScale, 4.5e13},
+		{"3.4 billion", LongScale, 3.4e12},
+		{"2.5 milliard", LongScale, 2.5e9},
+		{"12,345", ShortScale, 12345},
+		{"-2.5million", ShortScale, -2.5e6},
+		{"12.3 thousand", ShortScale, 12300},
+	}
+	for _, test := range tests {
+		got, err := ParseScaleWords(test.in, test.scale)
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", test.in, err)
+		} else if got != test.exp {
+			t.Errorf("Expected %v for %q, got %v", test.exp, test.in, got)
+		}
+	}
+
+	for _, bad := range []string{"", "million", "1.2 millions", "3 milliard", "1.2.3 million"} {
+		if got, err := ParseScaleWords(bad, ShortScale); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", bad, got)
+		}
+	}
+}
+
+func TestParseBigScaleWords(t *testing.T) {
+	got, err := ParseBigScaleWords("45.5 decillion", ShortScale)
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	if exp := "45500000000000000000000000000000000"; got.String() != exp {
+		t.Errorf("Expected %v, got %v", exp, got)
+	}
+	if got, err := ParseBigScaleWords("1.2345 thousand", ShortScale); err == nil {
+		t.Errorf("Expected error parsing a fraction, got %v", got)
+	}
+	for _, n := range []int64{0, 7, 1234567, -3000000000} {
+		s := BigScaleWordsWithOptions(big.NewInt(n), ScaleOptions{})
+		got, err := ParseBigScaleWords(s, ShortScale)
+		if err != nil || got.Int64() != n {
+			t.Errorf("Round trip of %v through %q gave %v, %v", n, s, got, err)
+		}
+	}
+}
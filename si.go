@@ -2,9 +2,12 @@ package humanize
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 var siPrefixTable = map[float64]string{
@@ -31,8 +34,31 @@ var siPrefixTable = map[float64]string{
 	30:  "Q", // quetta
 }
 
+// siExtraPrefixTable holds the prefixes that are not a power of 1000,
+// which ComputeSI doesn't use but SIPrefixSet allows.
+var siExtraPrefixTable = map[float64]string{
+	-2: "c",  // centi
+	-1: "d",  // deci
+	1:  "da", // deca
+	2:  "h",  // hecto
+}
+
 var revSIPrefixTable = revfmap(siPrefixTable)
 
+// decimalExponent returns the power of ten of the first significant
+// digit of input, or 0 for zero, infinities and NaN.
+//
+// This works on the decimal representation of input rather than
+// comparing against powers of ten, which aren't all exact.
+func decimalExponent(input float64) int {
+	if input == 0 || math.IsInf(input, 0) || math.IsNaN(input) {
+		return 0
+	}
+	s := strconv.FormatFloat(input, 'e', -1, 64)
+	exp10, _ := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	return exp10
+}
+
 // revfmap reverses the map and precomputes the power multiplier
 func revfmap(in map[float64]string) map[string]float64 {
 	rv := map[string]float64{}
@@ -42,10 +68,14 @@ func revfmap(in map[float64]string) map[string]float64 {
 	return rv
 }
 
+// siNumberPattern matches the number at the start of an SI string,
+// e.g. "-2.5", "+3" or "1.5e3".
+const siNumberPattern = `^([+\-]?[0-9.]+(?:[eE][+\-]?[0-9]+)?)`
+
 var riParseRegex *regexp.Regexp
 
 func init() {
-	ri := `^([\-0-9.]+)\s?([`
+	ri := siNumberPattern + `\s?([`
 	for _, v := range siPrefixTable {
 		ri += v
 	}
@@ -54,45 +84,148 @@ func init() {
 	riParseRegex = regexp.MustCompile(ri)
 }
 
+// SIPrefix is a metric prefix and the power of ten it stands for.
+type SIPrefix struct {
+	Symbol   string
+	Exponent int
+}
+
+// siPrefixes holds the prefixes of siPrefixTable, ordered by exponent,
+// and allSIPrefixes adds those of siExtraPrefixTable.
+var (
+	siPrefixes    = sortedSIPrefixes(siPrefixTable)
+	allSIPrefixes = sortedSIPrefixes(siPrefixTable, siExtraPrefixTable)
+)
+
+func sortedSIPrefixes(tables ...map[float64]string) []SIPrefix {
+	var rv []SIPrefix
+	for _, table := range tables {
+		for k, v := range table {
+			rv = append(rv, SIPrefix{v, int(k)})
+		}
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Exponent < rv[j].Exponent })
+	return rv
+}
+
+// SIPrefixRange returns the prefixes ComputeSI uses, from min to max
+// inclusive, for restricting the prefixes of SIWithOptions and
+// ParseSIWithOptions.  An empty string stands for no prefix.
+//
+// SIPrefixRange panics if min or max is not one of those prefixes.
+//
+// e.g. SIPrefixRange("", "G") -> "", k, M and G
+func SIPrefixRange(min, max string) []SIPrefix {
+	lo, hi := -1, -1
+	for i, p := range siPrefixes {
+		if p.Symbol == min {
+			lo = i
+		}
+		if p.Symbol == max {
+			hi = i
+		}
+	}
+	if lo < 0 || hi < 0 {
+		panic(fmt.Sprintf("SIPrefixRange(): unknown prefix in %q..%q", min, max))
+	}
+	return append([]SIPrefix(nil), siPrefixes[lo:hi+1]...)
+}
+
+// SIPrefixSet returns the named prefixes, for restricting or extending
+// the prefixes of SIWithOptions and ParseSIWithOptions.  Besides the
+// prefixes ComputeSI uses, it knows "h" (hecto), "da" (deca), "d"
+// (deci) and "c" (centi).  An empty string stands for no prefix.
+//
+// SIPrefixSet panics if a symbol is not a known prefix.
+//
+// e.g. SIPrefixSet("c", "", "k") -> c, "" and k
+func SIPrefixSet(symbols ...string) []SIPrefix {
+	var rv []SIPrefix
+	for _, p := range allSIPrefixes {
+		for _, s := range symbols {
+			if p.Symbol == s {
+				rv = append(rv, p)
+				break
+			}
+		}
+	}
+	if len(rv) != len(symbols) {
+		panic(fmt.Sprintf("SIPrefixSet(): unknown prefix in %q", symbols))
+	}
+	return rv
+}
+
+// pickSIPrefix returns the index of the largest of the prefixes,
+// ordered by exponent, that keeps the magnitude of input at least 1.
+// Zero, infinities and NaN get the prefix closest to no prefix at all.
+func pickSIPrefix(input float64, prefixes []SIPrefix) int {
+	exp10 := decimalExponent(input)
+	i := 0
+	for j, p := range prefixes {
+		if exp10 >= p.Exponent {
+			i = j
+		}
+	}
+	return i
+}
+
 // ComputeSI finds the most appropriate SI prefix for the given number
 // and returns the prefix along with the value adjusted to be within
 // that prefix.
 //
-// See also: SI, ParseSI.
+// See also: SI, ParseSI, ComputeSIWithPrefixes.
 //
 // e.g. ComputeSI(2.2345e-12) -> (2.2345, "p")
 func ComputeSI(input float64) (float64, string) {
-	if input == 0 {
-		return 0, ""
-	}
-	mag := math.Abs(input)
-	exponent := math.Floor(logn(mag, 10))
-	exponent = math.Floor(exponent/3) * 3
-
-	value := mag / math.Pow(10, exponent)
+	return ComputeSIWithPrefixes(input, siPrefixes)
+}
 
-	// Handle special case where value is exactly 1000.0
-	// Should return 1 M instead of 1000 k
-	if value == 1000.0 {
-		exponent += 3
-		value = mag / math.Pow(10, exponent)
+// ComputeSIWithPrefixes works like ComputeSI, choosing from the given
+// prefixes only.  Values beyond the range of the prefixes are
+// expressed with the largest or smallest of them.
+//
+// See also: SIPrefixRange, SIPrefixSet.
+//
+// e.g. ComputeSIWithPrefixes(1.2e6, SIPrefixRange("", "k")) -> (1200, "k")
+// e.g. ComputeSIWithPrefixes(0.5, SIPrefixSet("c", "")) -> (50, "c")
+func ComputeSIWithPrefixes(input float64, prefixes []SIPrefix) (float64, string) {
+	if len(prefixes) == 0 {
+		return input, ""
 	}
+	prefixes = sortedPrefixList(prefixes)
+	p := prefixes[pickSIPrefix(input, prefixes)]
+	if math.IsInf(input, 0) || math.IsNaN(input) {
+		return input, p.Symbol
+	}
+	value, _ := strconv.ParseFloat(shiftDecimal(input, -p.Exponent), 64)
+	return value, p.Symbol
+}
 
-	value = math.Copysign(value, input)
-
-	prefix := siPrefixTable[exponent]
-	return value, prefix
+// sortedPrefixList returns prefixes ordered by exponent, copying them
+// only if needed.
+func sortedPrefixList(prefixes []SIPrefix) []SIPrefix {
+	less := func(i, j int) bool { return prefixes[i].Exponent < prefixes[j].Exponent }
+	if !sort.SliceIsSorted(prefixes, less) {
+		prefixes = append([]SIPrefix(nil), prefixes...)
+		sort.Slice(prefixes, func(i, j int) bool { return prefixes[i].Exponent < prefixes[j].Exponent })
+	}
+	return prefixes
 }
 
 // SI returns a string with default formatting.
 //
-// SI uses Ftoa to format float value, removing trailing zeros.
+// SI uses Ftoa to format float value, removing trailing zeros.  Units
+// that can't take a prefix, such as "%" or "°C", get none.
 //
-// See also: ComputeSI, ParseSI.
+// See also: ComputeSI, ParseSI, Prefixable.
 //
 // e.g. SI(1000000, "B") -> 1 MB
 // e.g. SI(2.2345e-12, "F") -> 2.2345 pF
+// e.g. SI(1200, "%") -> 1200 %
 func SI(input float64, unit string) string {
+	if !Prefixable(unit) {
+		return Ftoa(input) + " " + unit
+	}
 	value, prefix := ComputeSI(input)
 	return Ftoa(value) + " " + prefix + unit
 }
@@ -100,20 +233,181 @@ func SI(input float64, unit string) string {
 // SIWithDigits works like SI but limits the resulting string to the
 // given number of decimal places.
 //
+// The value is rounded to nearest, with ties to even, and moves on to
+// the next prefix if it rounds up to 1000.
+//
+// See also: SIWithRounding, SIWithOptions.
+//
 // e.g. SIWithDigits(1000000, 0, "B") -> 1 MB
 // e.g. SIWithDigits(2.2345e-12, 2, "F") -> 2.23 pF
+// e.g. SIWithDigits(2.2399e-12, 2, "F") -> 2.24 pF
 func SIWithDigits(input float64, decimals int, unit string) string {
-	value, prefix := ComputeSI(input)
-	return FtoaWithDigits(value, decimals) + " " + prefix + unit
+	return SIWithRounding(input, decimals, unit, RoundHalfEven)
+}
+
+// SIWithRounding works like SIWithDigits, rounding the value with the
+// given mode.
+//
+// e.g. SIWithRounding(2.2399e-12, 2, "F", RoundTowardZero) -> 2.23 pF
+func SIWithRounding(input float64, decimals int, unit string, mode RoundingMode) string {
+	return SIWithOptions(input, unit, SIOptions{
+		Precision: SIDecimals,
+		Digits:    decimals,
+		Rounding:  mode,
+	})
+}
+
+// SIPrecision selects how SIWithOptions rounds the value.
+type SIPrecision int
+
+const (
+	// SIShortest formats the value with Ftoa, as SI does.
+	SIShortest SIPrecision = iota
+	// SIDecimals rounds the value to SIOptions.Digits decimal
+	// places and removes trailing zeros.
+	SIDecimals
+	// SIFixed rounds the value to exactly SIOptions.Digits decimal
+	// places, keeping trailing zeros.
+	SIFixed
+	// SISignificant rounds the value to SIOptions.Digits significant
+	// figures, keeping trailing zeros.
+	SISignificant
+)
+
+// SIOptions controls how SIWithOptions formats a value.  The zero
+// value formats the same way as SI.
+type SIOptions struct {
+	// Precision selects how the value is rounded, and Digits is
+	// the number of decimal places or significant figures it
+	// rounds to.
+	Precision SIPrecision
+	Digits    int
+
+	// Engineering writes the power of ten as an exponent, as in
+	// "1.23e6 Hz", instead of using an SI prefix.
+	Engineering bool
+
+	// NoSpace leaves out the space between the value and the
+	// prefix, as in "1.2kHz".
+	NoSpace bool
+
+	// Rounding selects how the value is rounded.  The default
+	// rounds to nearest, with ties to even.
+	Rounding RoundingMode
+
+	// Prefixes restricts or extends the prefixes to choose from,
+	// as built by SIPrefixRange or SIPrefixSet.  By default, the
+	// prefixes ComputeSI uses are allowed, and engineering
+	// notation allows any multiple of three.
+	Prefixes []SIPrefix
+
+	// ASCII writes the micro prefix as "u" instead of "µ", and
+	// lets ParseSIWithOptions accept it.
+	ASCII bool
+}
+
+// SIWithOptions works like SI but lets opts control the rounding of
+// the value, the use of prefixes and the spacing.  As with SI, units
+// that can't take a prefix get none.
+//
+// A value that rounds up to the next prefix (e.g. 999.96 to one
+// decimal place) moves on to that prefix.
+//
+// e.g. SIWithOptions(2.2399e-12, "F", SIOptions{Precision: SIDecimals, Digits: 2}) -> 2.24 pF
+// e.g. SIWithOptions(1234, "W", SIOptions{Precision: SISignificant, Digits: 3}) -> 1.23 kW
+// e.g. SIWithOptions(999.96, "V", SIOptions{Precision: SIFixed, Digits: 1}) -> 1.0 kV
+// e.g. SIWithOptions(1.23e6, "Hz", SIOptions{Engineering: true}) -> 1.23e6 Hz
+// e.g. SIWithOptions(1.2e6, "m", SIOptions{Prefixes: SIPrefixRange("", "k")}) -> 1200 km
+func SIWithOptions(input float64, unit string, opts SIOptions) string {
+	prefixes := opts.Prefixes
+	if prefixes == nil && !opts.Engineering {
+		prefixes = siPrefixes
+	}
+	if !Prefixable(unit) && !opts.Engineering {
+		prefixes = []SIPrefix{}
+	}
+
+	var s, prefix string
+	var exponent int
+	if prefixes == nil {
+		exponent = decimalExponent(input)
+		if exponent < 0 {
+			exponent -= 2
+		}
+		exponent -= exponent % 3
+		s = formatSIValue(input, exponent, opts)
+		if decimalMagnitude(s) > 3 {
+			exponent += 3
+			s = formatSIValue(input, exponent, opts)
+		}
+	} else if len(prefixes) == 0 {
+		s = formatSIValue(input, 0, opts)
+	} else {
+		prefixes = sortedPrefixList(prefixes)
+		i := pickSIPrefix(input, prefixes)
+		s = formatSIValue(input, prefixes[i].Exponent, opts)
+		// Rounding may have carried the value up to the next prefix.
+		if i+1 < len(prefixes) && decimalMagnitude(s) > prefixes[i+1].Exponent-prefixes[i].Exponent {
+			i++
+			s = formatSIValue(input, prefixes[i].Exponent, opts)
+		}
+		exponent, prefix = prefixes[i].Exponent, prefixes[i].Symbol
+	}
+
+	sep := " "
+	if opts.NoSpace {
+		sep = ""
+	}
+	if opts.Engineering {
+		if exponent != 0 {
+			s += "e" + strconv.Itoa(exponent)
+		}
+		return s + sep + unit
+	}
+	if opts.ASCII && prefix == "µ" {
+		prefix = "u"
+	}
+	return s + sep + prefix + unit
+}
+
+// formatSIValue rounds input, scaled to the given power of ten, as
+// opts asks.
+func formatSIValue(input float64, exponent int, opts SIOptions) string {
+	if math.IsInf(input, 0) || math.IsNaN(input) {
+		return strconv.FormatFloat(input, 'f', -1, 64)
+	}
+	return roundPrecision(shiftDecimal(input, -exponent), opts.Precision, opts.Digits, opts.Rounding)
+}
+
+// roundPrecision rounds the plain decimal number s as the precision
+// and digits of SIOptions describe.
+func roundPrecision(s string, precision SIPrecision, digits int, mode RoundingMode) string {
+	if digits < 0 {
+		digits = 0
+	}
+	switch precision {
+	case SIDecimals:
+		return stripTrailingZeros(roundDecimal(s, digits, mode))
+	case SIFixed:
+		return roundDecimal(s, digits, mode)
+	case SISignificant:
+		return roundSignificant(s, digits, mode)
+	}
+	return stripTrailingZeros(roundDecimal(s, 6, mode))
 }
 
 var errInvalid = errors.New("invalid input")
 
 // ParseSI parses an SI string back into the number and unit.
 //
+// The number may have a sign and an exponent.  Any character
+// following it that could be a prefix is taken as one, so use
+// UnitRegistry.ParseSI when units such as "m" or "Pa" are expected.
+//
 // See also: SI, ComputeSI.
 //
 // e.g. ParseSI("2.2345 pF") -> (2.2345e-12, "F", nil)
+// e.g. ParseSI("+1.5e3 Hz") -> (1500, "Hz", nil)
 func ParseSI(input string) (float64, string, error) {
 	found := riParseRegex.FindStringSubmatch(input)
 	if len(found) != 4 {
@@ -125,3 +419,52 @@ func ParseSI(input string) (float64, string, error) {
 	base, err := strconv.ParseFloat(found[1], 64)
 	return base * mag, unit, err
 }
+
+var siNumberRegex = regexp.MustCompile(siNumberPattern + `\s?(.*)`)
+
+// ParseSIWithOptions works like ParseSI, recognizing only the prefixes
+// allowed by opts.Prefixes, and "u" for micro if opts.ASCII is set.
+// The other options are ignored.
+//
+// See also: SIWithOptions.
+//
+// e.g. ParseSIWithOptions("2 hPa", SIOptions{Prefixes: SIPrefixSet("", "h", "k")}) -> (200, "Pa", nil)
+// e.g. ParseSIWithOptions("2.2 uF", SIOptions{ASCII: true}) -> (2.2e-6, "F", nil)
+func ParseSIWithOptions(input string, opts SIOptions) (float64, string, error) {
+	found := siNumberRegex.FindStringSubmatch(input)
+	if len(found) != 3 {
+		return 0, "", errInvalid
+	}
+	num, rest := found[1], found[2]
+
+	prefixes := opts.Prefixes
+	if prefixes == nil {
+		prefixes = siPrefixes
+	}
+	exponent, longest := 0, 0
+	for _, p := range prefixes {
+		symbol := p.Symbol
+		if opts.ASCII && symbol == "µ" && strings.HasPrefix(rest, "u") {
+			symbol = "u"
+		}
+		if len(symbol) > longest && strings.HasPrefix(rest, symbol) {
+			exponent, longest = p.Exponent, len(symbol)
+		}
+	}
+
+	v, err := parseSINumber(num, exponent)
+	return v, rest[longest:], err
+}
+
+// parseSINumber parses num scaled by the given power of ten, adjusting
+// the exponent rather than multiplying, so the result is exact.
+func parseSINumber(num string, exponent int) (float64, error) {
+	if i := strings.IndexAny(num, "eE"); i >= 0 {
+		e, err := strconv.Atoi(num[i+1:])
+		if err != nil {
+			return 0, err
+		}
+		num, exponent = num[:i], exponent+e
+	}
+	return strconv.ParseFloat(num+"e"+strconv.Itoa(exponent), 64)
+}
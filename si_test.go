@@ -110,6 +110,11 @@ func TestSIWithDigits(t *testing.T) {
 		{"e-12", 2.234e-12, 2, "2.23 pF"},
 		{"e-12", 2.234e-12, 3, "2.234 pF"},
 		{"e-12", 2.234e-12, 4, "2.234 pF"},
+		{"e-12", 2.2399e-12, 2, "2.24 pF"},
+		{"e-12", 2.2399e-12, 8, "2.2399 pF"},
+		{"e-3", 999.9996e-3, 3, "1 F"},
+		{"e+3", 999999, 2, "1 MF"},
+		{"e+30", 2.2e30, 1, "2.2 QF"},
 	}
 
 	for _, test := range tests {
@@ -121,6 +126,16 @@ func TestSIWithDigits(t *testing.T) {
 	}
 }
 
+func TestSIWithRounding(t *testing.T) {
+	testList{
+		{"half even", SIWithRounding(2.225e3, 2, "W", RoundHalfEven), "2.22 kW"},
+		{"half up", SIWithRounding(2.225e3, 2, "W", RoundHalfUp), "2.23 kW"},
+		{"toward zero", SIWithRounding(2.2399e-12, 2, "F", RoundTowardZero), "2.23 pF"},
+		{"toward zero no carry", SIWithRounding(999.99e3, 1, "W", RoundTowardZero), "999.9 kW"},
+		{"options", SIWithOptions(2.225e3, "W", SIOptions{Precision: SIFixed, Digits: 2, Rounding: RoundHalfUp}), "2.23 kW"},
+	}.validate(t)
+}
+
 func BenchmarkParseSI(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ParseSI("2.2346ZB")
@@ -143,3 +158,168 @@ func TestBug106(t *testing.T) {
 		}
 	}
 }
+
+func TestSIWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		num       float64
+		unit      string
+		opts      SIOptions
+		formatted string
+	}{
+		{"default", 2.2345e-12, "F", SIOptions{}, "2.2345 pF"},
+		{"default zero", 0, "F", SIOptions{}, "0 F"},
+		{"decimals round", 2.2399e-12, "F", SIOptions{Precision: SIDecimals, Digits: 2}, "2.24 pF"},
+		{"decimals strip", 2.2e-12, "F", SIOptions{Precision: SIDecimals, Digits: 2}, "2.2 pF"},
+		{"decimals 0", 2.5e3, "W", SIOptions{Precision: SIDecimals}, "2 kW"},
+		{"fixed", 2.2e-12, "F", SIOptions{Precision: SIFixed, Digits: 3}, "2.200 pF"},
+		{"fixed 0", 1500, "W", SIOptions{Precision: SIFixed}, "2 kW"},
+		{"fixed carry", 999.96, "V", SIOptions{Precision: SIFixed, Digits: 1}, "1.0 kV"},
+		{"fixed carry k", 999960, "V", SIOptions{Precision: SIFixed, Digits: 1}, "1.0 MV"},
+		{"fixed carry neg", -999960, "V", SIOptions{Precision: SIFixed, Digits: 1}, "-1.0 MV"},
+		{"sig 3", 1234, "W", SIOptions{Precision: SISignificant, Digits: 3}, "1.23 kW"},
+		{"sig 3 tens", 12345, "W", SIOptions{Precision: SISignificant, Digits: 3}, "12.3 kW"},
+		{"sig 3 hundreds", 123456, "W", SIOptions{Precision: SISignificant, Digits: 3}, "123 kW"},
+		{"sig 2 hundreds", 123456, "W", SIOptions{Precision: SISignificant, Digits: 2}, "120 kW"},
+		{"sig zeros", 1200, "W", SIOptions{Precision: SISignificant, Digits: 3}, "1.20 kW"},
+		{"sig carry digit", 9.996, "W", SIOptions{Precision: SISignificant, Digits: 3}, "10.0 W"},
+		{"sig carry prefix", 999.6e3, "W", SIOptions{Precision: SISignificant, Digits: 3}, "1.00 MW"},
+		{"sig zero", 0, "W", SIOptions{Precision: SISignificant, Digits: 3}, "0.00 W"},
+		{"engineering", 1.23e6, "Hz", SIOptions{Engineering: true}, "1.23e6 Hz"},
+		{"engineering small", 2.2e-12, "F", SIOptions{Engineering: true}, "2.2e-12 F"},
+		{"engineering unit", 2.2, "F", SIOptions{Engineering: true}, "2.2 F"},
+		{"engineering beyond prefixes", 4.5e36, "J", SIOptions{Engineering: true}, "4.5e36 J"},
+		{"engineering sig", 123456, "Hz", SIOptions{Engineering: true, Precision: SISignificant, Digits: 2}, "120e3 Hz"},
+		{"no space", 1200, "Hz", SIOptions{NoSpace: true}, "1.2kHz"},
+		{"no space engineering", 1200, "Hz", SIOptions{NoSpace: true, Engineering: true}, "1.2e3Hz"},
+	}
+
+	for _, test := range tests {
+		got := SIWithOptions(test.num, test.unit, test.opts)
+		if got != test.formatted {
+			t.Errorf("On %v (%v), got %v, wanted %v",
+				test.name, test.num, got, test.formatted)
+		}
+	}
+}
+
+func TestComputeSIWithPrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		num      float64
+		prefixes []SIPrefix
+		value    float64
+		prefix   string
+	}{
+		{"clamp max", 1.2e6, SIPrefixRange("", "k"), 1200, "k"},
+		{"clamp min", 2e-6, SIPrefixRange("m", "M"), 0.002, "m"},
+		{"no prefix", 1.5, SIPrefixRange("", "G"), 1.5, ""},
+		{"only kMG", 500, SIPrefixRange("k", "G"), 0.5, "k"},
+		{"centi", 0.5, SIPrefixSet("c", ""), 50, "c"},
+		{"hecto", 1013e2, SIPrefixSet("", "h", "k"), 101.3, "k"},
+		{"hecto below k", 250, SIPrefixSet("", "h", "k"), 2.5, "h"},
+		{"unsorted", 2e6, []SIPrefix{{"k", 3}, {"", 0}}, 2000, "k"},
+		{"zero", 0, SIPrefixRange("k", "G"), 0, "k"},
+		{"none", 42, nil, 42, ""},
+		{"beyond quetta", 1e33, siPrefixes, 1000, "Q"},
+		{"below quecto", 1e-33, siPrefixes, 0.001, "q"},
+	}
+
+	for _, test := range tests {
+		value, prefix := ComputeSIWithPrefixes(test.num, test.prefixes)
+		if math.Abs(value-test.value) > 1e-9 || prefix != test.prefix {
+			t.Errorf("On %v (%v), got %v %q, wanted %v %q",
+				test.name, test.num, value, prefix, test.value, test.prefix)
+		}
+	}
+}
+
+func TestSIPrefixSetPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"range": func() { SIPrefixRange("k", "x") },
+		"set":   func() { SIPrefixSet("k", "x") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("On %v, should have panicked", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSIWithPrefixes(t *testing.T) {
+	kMG := SIOptions{Prefixes: SIPrefixRange("", "G")}
+	testList{
+		{"clamped", SIWithOptions(1.2e12, "W", kMG), "1200 GW"},
+		{"clamped small", SIWithOptions(1.2e-3, "W", kMG), "0.0012 W"},
+		{"hecto", SIWithOptions(101325, "Pa", SIOptions{Prefixes: SIPrefixSet("", "h")}), "1013.25 hPa"},
+		{"centi", SIWithOptions(0.25, "m", SIOptions{Prefixes: SIPrefixSet("c", "", "k")}), "25 cm"},
+		{"deca carry", SIWithOptions(9.99, "m", SIOptions{Prefixes: SIPrefixSet("", "da"), Precision: SIDecimals, Digits: 1}), "1 dam"},
+		{"ascii", SIWithOptions(2.2e-6, "F", SIOptions{ASCII: true}), "2.2 uF"},
+		{"engineering prefixes", SIWithOptions(1.2e12, "W", SIOptions{Engineering: true, Prefixes: SIPrefixRange("", "G")}), "1200e9 W"},
+		{"empty prefixes", SIWithOptions(1.2e6, "W", SIOptions{Prefixes: []SIPrefix{}}), "1200000 W"},
+	}.validate(t)
+}
+
+func TestParseSIWithOptions(t *testing.T) {
+	tests := []struct {
+		in    string
+		opts  SIOptions
+		value float64
+		unit  string
+	}{
+		{"2.2345 pF", SIOptions{}, 2.2345e-12, "F"},
+		{"2 hPa", SIOptions{Prefixes: SIPrefixSet("", "h", "k")}, 200, "Pa"},
+		{"2 dam", SIOptions{Prefixes: SIPrefixSet("d", "", "da")}, 20, "m"},
+		{"2 dm", SIOptions{Prefixes: SIPrefixSet("d", "", "da")}, 0.2, "m"},
+		{"2 mm", SIOptions{Prefixes: SIPrefixRange("", "G")}, 2, "mm"},
+		{"2.2 uF", SIOptions{ASCII: true}, 2.2e-6, "F"},
+		{"2.2 µF", SIOptions{ASCII: true}, 2.2e-6, "F"},
+		{"2.2 uF", SIOptions{}, 2.2, "uF"},
+	}
+
+	for _, test := range tests {
+		value, unit, err := ParseSIWithOptions(test.in, test.opts)
+		if err != nil {
+			t.Errorf("Error parsing %v: %v", test.in, err)
+			continue
+		}
+		if value != test.value || unit != test.unit {
+			t.Errorf("On %v, got %v %q, wanted %v %q",
+				test.in, value, unit, test.value, test.unit)
+		}
+	}
+
+	if _, _, err := ParseSIWithOptions("x1.21JW", SIOptions{}); err == nil {
+		t.Errorf("Expected error on x1.21JW")
+	}
+}
+
+func TestParseSIExponent(t *testing.T) {
+	tests := []struct {
+		in    string
+		value float64
+		unit  string
+	}{
+		{"1.5e3 Hz", 1500, "Hz"},
+		{"1.5E-3 Hz", 1.5e-3, "Hz"},
+		{"+1.5 kHz", 1500, "Hz"},
+		{"2EB", 2e18, "B"},
+		{"2e2 kW", 2e5, "W"},
+	}
+
+	for _, test := range tests {
+		value, unit, err := ParseSI(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %v: %v", test.in, err)
+			continue
+		}
+		if math.Abs(1-value/test.value) > 1e-12 || unit != test.unit {
+			t.Errorf("On %v, got %v %q, wanted %v %q",
+				test.in, value, unit, test.value, test.unit)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package humanize
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SIQuantity is a value with an SI unit, such as 4.7e-6 F, that reads
+// and writes itself with an SI prefix ("4.7 µF").
+//
+// It implements flag.Value, encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler, json.Unmarshaler and
+// fmt.Formatter.
+//
+// When decoding into an SIQuantity whose Unit is already set, the
+// decoded unit must match it, which lets a configuration field
+// declare what it expects:
+//
+//	cfg := struct{ Capacitance humanize.SIQuantity }{
+//		Capacitance: humanize.SIQuantity{Unit: "F"},
+//	}
+//	err := json.Unmarshal(data, &cfg) // fails on "4.7 µH"
+//
+// See also: SI, ParseSI.
+type SIQuantity struct {
+	Value float64
+	Unit  string
+}
+
+// ParseSIQuantity parses an SI string such as "4.7 µF" into an
+// SIQuantity.
+//
+// Unlike ParseSI, the prefix is applied by shifting the decimal point
+// rather than by multiplication, and units in DefaultUnits are
+// recognized before prefixes, so "5 m" is five metres and "3 Pa" three
+// pascals, as UnitRegistry.ParseSI reads them.  Every SIQuantity whose
+// unit is in DefaultUnits, or doesn't start with a prefix, survives
+// being formatted and parsed again unchanged.  Other units take any
+// character that could be a prefix as one, as ParseSI does, so "12 ft"
+// is read as femto-tonnes.
+//
+// See also: ParseSI.
+func ParseSIQuantity(s string) (SIQuantity, error) {
+	in := strings.TrimSpace(s)
+	if found := siNumberRegex.FindStringSubmatch(in); len(found) == 3 {
+		if prefix, unit, ok := DefaultUnits.splitUnit(found[2]); ok {
+			v, err := parseSINumber(found[1], prefix.Exponent)
+			if err != nil {
+				return SIQuantity{}, err
+			}
+			return SIQuantity{v, unit}, nil
+		}
+	}
+	found := riParseRegex.FindStringSubmatch(in)
+	if len(found) != 4 {
+		return SIQuantity{}, errInvalid
+	}
+	v, err := parseSINumber(found[1], siExponent(found[2]))
+	if err != nil {
+		return SIQuantity{}, err
+	}
+	return SIQuantity{v, found[3]}, nil
+}
+
+// siExponent returns the power of ten of an SI prefix.
+func siExponent(prefix string) int {
+	for k, v := range siPrefixTable {
+		if v == prefix {
+			return int(k)
+		}
+	}
+	return 0
+}
+
+// exactSI formats input like SI, but with all the digits needed to
+// represent input exactly.  Units that can't take a prefix are written
+// without one.
+func exactSI(input float64, unit string) string {
+	if !Prefixable(unit) {
+		return shiftDecimal(input, 0) + " " + unit
+	}
+	_, prefix := ComputeSI(input)
+	return shiftDecimal(input, -siExponent(prefix)) + " " + prefix + unit
+}
+
+// String returns the quantity with an SI prefix and all the decimal
+// places needed to represent Value exactly, e.g. "4.7 µF".
+func (q SIQuantity) String() string {
+	return exactSI(q.Value, q.Unit)
+}
+
+// parseExpected parses s as a quantity of q's unit: the unit is taken
+// off the end of s first, so that only what is left before it may be
+// a prefix, as in "5 m" or "3 Pa".
+func (q *SIQuantity) parseExpected(s string) (SIQuantity, error) {
+	in := strings.TrimSpace(s)
+	if !strings.HasSuffix(in, q.Unit) {
+		p, err := ParseSIQuantity(in)
+		if err != nil {
+			return SIQuantity{}, err
+		}
+		return SIQuantity{}, fmt.Errorf("unexpected unit %q, want %q", p.Unit, q.Unit)
+	}
+	found := siNumberRegex.FindStringSubmatch(strings.TrimSuffix(in, q.Unit))
+	if len(found) != 3 {
+		return SIQuantity{}, errInvalid
+	}
+	prefix := strings.TrimSpace(found[2])
+	if _, ok := revSIPrefixTable[prefix]; !ok || prefix != "" && !Prefixable(q.Unit) {
+		return SIQuantity{}, fmt.Errorf("unexpected unit %q, want %q", prefix+q.Unit, q.Unit)
+	}
+	v, err := parseSINumber(found[1], siExponent(prefix))
+	if err != nil {
+		return SIQuantity{}, err
+	}
+	return SIQuantity{v, q.Unit}, nil
+}
+
+// Set parses a quantity such as "4.7 µF" as ParseSI does.  It
+// implements flag.Value.
+//
+// When q's Unit is set, the quantity must be in that unit, with or
+// without a prefix.
+func (q *SIQuantity) Set(s string) error {
+	var p SIQuantity
+	var err error
+	if q.Unit != "" {
+		p, err = q.parseExpected(s)
+	} else {
+		p, err = ParseSIQuantity(s)
+	}
+	if err != nil {
+		return err
+	}
+	*q = p
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (q SIQuantity) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (q *SIQuantity) UnmarshalText(text []byte) error {
+	return q.Set(string(text))
+}
+
+// MarshalJSON implements json.Marshaler.  The quantity is encoded as
+// a string.
+func (q SIQuantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  Strings such as
+// "4.7 µF" are parsed with ParseSI, while plain numbers set Value
+// and keep the expected Unit.
+func (q *SIQuantity) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	switch {
+	case s == "null":
+		return nil
+	case strings.HasPrefix(s, `"`):
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return q.Set(s)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	q.Value = v
+	return nil
+}
+
+// Format implements fmt.Formatter.
+//
+// %v, %s and %q use String, %e, %f and %g print Value without a
+// prefix or unit, and %h prints SI, or SIWithDigits when a precision
+// is given.
+func (q SIQuantity) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'h':
+		if p, ok := f.Precision(); ok {
+			padFormat(f, SIWithDigits(q.Value, p, q.Unit))
+		} else {
+			padFormat(f, SI(q.Value, q.Unit))
+		}
+	case 'v', 's', 'q':
+		fmt.Fprintf(f, formatDirective(f, verb), q.String())
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		fmt.Fprintf(f, formatDirective(f, verb), q.Value)
+	default:
+		fmt.Fprintf(f, "%%!%c(humanize.SIQuantity=%v %v)", verb, q.Value, q.Unit)
+	}
+}
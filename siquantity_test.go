@@ -0,0 +1,170 @@
+package humanize
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSIQuantityString(t *testing.T) {
+	testList{
+		{"4.7µF", SIQuantity{4.7e-6, "F"}.String(), "4.7 µF"},
+		{"1MHz", SIQuantity{1e6, "Hz"}.String(), "1 MHz"},
+		{"0", SIQuantity{0, "V"}.String(), "0 V"},
+		{"-2.2kW", SIQuantity{-2200, "W"}.String(), "-2.2 kW"},
+		{"precise", SIQuantity{1.23456789e-12, "F"}.String(), "1.23456789 pF"},
+		{"inf", SIQuantity{math.Inf(1), "F"}.String(), "+Inf F"},
+		{"percent", SIQuantity{1200, "%"}.String(), "1200 %"},
+		{"decibels", SIQuantity{1500, "dB"}.String(), "1500 dB"},
+		{"celsius", SIQuantity{0.02, "°C"}.String(), "0.02 °C"},
+	}.validate(t)
+}
+
+func TestSIQuantityRoundTrip(t *testing.T) {
+	for _, q := range []SIQuantity{
+		{4.7e-6, "F"},
+		{1.23456789e-12, "F"},
+		{2.2e-9, "H"},
+		{123456.789, "Hz"},
+		{-0.001, "A"},
+		{42, ""},
+		{5, "m"},
+		{0.005, "m"},
+		{3, "Pa"},
+		{3000, "Pa"},
+		{2, "T"},
+		{2e12, "T"},
+		{7, "min"},
+		{1, "mol"},
+		{2500, "W·h"},
+	} {
+		var got SIQuantity
+		if err := got.Set(q.String()); err != nil {
+			t.Errorf("Error parsing %v: %v", q, err)
+			continue
+		}
+		if got != q {
+			t.Errorf("Round trip of %#v gave %#v", q, got)
+		}
+
+		data, err := json.Marshal(q)
+		if err != nil {
+			t.Errorf("Error encoding %v: %v", q, err)
+			continue
+		}
+		var decoded SIQuantity
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != q {
+			t.Errorf("JSON round trip of %#v through %s gave %#v, %v", q, data, decoded, err)
+		}
+	}
+}
+
+func TestSIQuantityExpectedUnit(t *testing.T) {
+	q := SIQuantity{Unit: "F"}
+	if err := q.Set("4.7 µF"); err != nil {
+		t.Errorf("Error parsing farads: %v", err)
+	}
+	if err := q.Set("4.7 µH"); err == nil {
+		t.Errorf("Expected error parsing henries, got %v", q)
+	}
+	if q.Value != 4.7e-6 || q.Unit != "F" {
+		t.Errorf("Failed parse modified the quantity: %#v", q)
+	}
+
+	tests := []struct {
+		unit, in string
+		exp      float64
+	}{
+		{"m", "5 m", 5},
+		{"m", "5m", 5},
+		{"m", "2.5 km", 2500},
+		{"m", "3 mm", 0.003},
+		{"Pa", "3 Pa", 3},
+		{"Pa", "3 kPa", 3000},
+		{"min", "2 min", 2},
+		{"%", "12 %", 12},
+	}
+	for _, test := range tests {
+		q := SIQuantity{Unit: test.unit}
+		if err := q.Set(test.in); err != nil {
+			t.Errorf("Error parsing %q as %v: %v", test.in, test.unit, err)
+		} else if q.Value != test.exp || q.Unit != test.unit {
+			t.Errorf("Expected %v %v for %q, got %#v", test.exp, test.unit, test.in, q)
+		}
+	}
+
+	for _, bad := range []struct{ unit, in string }{
+		{"m", "5 Pa"},
+		{"m", "5 xm"},
+		{"Pa", "3 a"},
+		{"min", "2 kmin x"},
+		{"%", "1 k%"},
+	} {
+		q := SIQuantity{Unit: bad.unit}
+		if err := q.Set(bad.in); err == nil {
+			t.Errorf("Expected error parsing %q as %v, got %#v", bad.in, bad.unit, q)
+		}
+	}
+}
+
+func TestSIQuantityJSON(t *testing.T) {
+	cfg := struct {
+		Capacitance SIQuantity `json:"timeout_capacitance"`
+		Frequency   SIQuantity `json:"frequency"`
+		Other       SIQuantity `json:"other"`
+	}{
+		Capacitance: SIQuantity{Unit: "F"},
+		Frequency:   SIQuantity{Unit: "Hz"},
+	}
+	in := `{"timeout_capacitance": "4.7 µF", "frequency": 50, "other": "3 kV"}`
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatalf("Error decoding %s: %v", in, err)
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Error encoding: %v", err)
+	}
+	exp := `{"timeout_capacitance":"4.7 µF","frequency":"50 Hz","other":"3 kV"}`
+	if string(out) != exp {
+		t.Errorf("Expected %s, got %s", exp, out)
+	}
+
+	unprefixed := []SIQuantity{{1200, "%"}, {1500, "dB"}, {0.02, "°C"}}
+	out, err = json.Marshal(unprefixed)
+	if err != nil {
+		t.Fatalf("Error encoding: %v", err)
+	}
+	exp = `["1200 %","1500 dB","0.02 °C"]`
+	if string(out) != exp {
+		t.Errorf("Expected %s, got %s", exp, out)
+	}
+	var decoded []SIQuantity
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Error decoding %s: %v", out, err)
+	}
+	for i, q := range decoded {
+		if q != unprefixed[i] {
+			t.Errorf("Round trip of %#v gave %#v", unprefixed[i], q)
+		}
+	}
+
+	q := SIQuantity{Unit: "F"}
+	for _, bad := range []string{`"4.7 µH"`, `"x"`, `true`} {
+		if err := json.Unmarshal([]byte(bad), &q); err == nil {
+			t.Errorf("Expected error decoding %s, got %v", bad, q)
+		}
+	}
+}
+
+func TestSIQuantityFormat(t *testing.T) {
+	q := SIQuantity{2.2345e-12, "F"}
+	testList{
+		{"%v", fmt.Sprintf("%v", q), "2.2345 pF"},
+		{"%h", fmt.Sprintf("%h", q), "2.2345 pF"},
+		{"%.2h", fmt.Sprintf("%.2h", q), "2.23 pF"},
+		{"%10.1h", fmt.Sprintf("%10.1h", q), "    2.2 pF"},
+		{"%g", fmt.Sprintf("%g", q), "2.2345e-12"},
+		{"%d", fmt.Sprintf("%d", q), "%!d(humanize.SIQuantity=2.2345e-12 F)"},
+	}.validate(t)
+}
@@ -0,0 +1,409 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A UnitRegistry is a set of known units and how they relate to each
+// other.
+//
+// Knowing the units lets UnitRegistry.ParseSI tell whether the first
+// letter after a number is an SI prefix or part of the unit, so "5 m"
+// is five metres rather than five milli-nothing and "3 Pa" is three
+// pascals rather than three peta-"a".
+//
+// Knowing how units are defined in terms of each other lets
+// UnitRegistry.Convert change quantities between compatible units,
+// such as kW·h and J.
+//
+// A UnitRegistry is safe for concurrent use.
+type UnitRegistry struct {
+	mu    sync.RWMutex
+	units map[string]unitDef
+}
+
+// unitDef defines a unit as a multiple of a product of powers of base
+// units.  offset is the zero point of the unit in base units, for
+// temperature scales such as °C.
+type unitDef struct {
+	factor float64
+	offset float64
+	dim    map[string]int
+}
+
+// NewUnitRegistry returns a UnitRegistry knowing the given symbols as
+// base units.
+func NewUnitRegistry(symbols ...string) *UnitRegistry {
+	r := &UnitRegistry{units: map[string]unitDef{}}
+	r.Register(symbols...)
+	return r
+}
+
+// DefaultUnits knows the SI base and derived units, and the common
+// units accepted for use with them.
+var DefaultUnits = newDefaultUnits()
+
+func newDefaultUnits() *UnitRegistry {
+	// SI base units, with the gram standing in for the kilogram so
+	// that prefixes work as usual.  The bit is the base of information.
+	r := NewUnitRegistry("m", "g", "s", "A", "K", "mol", "cd", "bit")
+	// Logarithmic units don't scale linearly, so they can't be
+	// converted to anything else.
+	r.Register("dB", "Np")
+
+	defs := []struct {
+		symbol, definition string
+		factor             float64
+	}{
+		// SI derived units
+		{"rad", "", 1},
+		{"sr", "", 1},
+		{"Hz", "1/s", 1},
+		{"N", "kg·m/s²", 1},
+		{"Pa", "N/m²", 1},
+		{"J", "N·m", 1},
+		{"W", "J/s", 1},
+		{"C", "A·s", 1},
+		{"V", "W/A", 1},
+		{"F", "C/V", 1},
+		{"Ω", "V/A", 1},
+		{"S", "A/V", 1},
+		{"Wb", "V·s", 1},
+		{"T", "Wb/m²", 1},
+		{"H", "Wb/A", 1},
+		{"lm", "cd·sr", 1},
+		{"lx", "lm/m²", 1},
+		{"Bq", "1/s", 1},
+		{"Gy", "J/kg", 1},
+		{"Sv", "J/kg", 1},
+		{"kat", "mol/s", 1},
+		// Accepted for use with the SI
+		{"min", "s", 60},
+		{"h", "s", 3600},
+		{"d", "s", 86400},
+		{"L", "dm³", 1},
+		{"l", "dm³", 1},
+		{"t", "Mg", 1},
+		{"eV", "J", 1.602176634e-19},
+		{"Da", "kg", 1.66053906660e-27},
+		{"bar", "Pa", 1e5},
+		{"ha", "hm²", 1},
+		{"au", "m", 149597870700},
+		// Common non-SI units
+		{"Wh", "W·h", 1},
+		{"B", "bit", 8},
+	}
+	for _, d := range defs {
+		if err := r.Define(d.symbol, d.definition, d.factor); err != nil {
+			panic(err)
+		}
+	}
+
+	r.units["°C"] = unitDef{factor: 1, offset: 273.15, dim: map[string]int{"K": 1}}
+	r.units["°F"] = unitDef{factor: 5.0 / 9, offset: 459.67 * 5 / 9, dim: map[string]int{"K": 1}}
+	return r
+}
+
+// Register adds the given symbols to the registry as base units, each
+// of its own dimension, so they can be parsed but not converted to any
+// other unit.
+func (r *UnitRegistry) Register(symbols ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range symbols {
+		r.units[s] = unitDef{factor: 1, dim: map[string]int{s: 1}}
+	}
+}
+
+// Define adds symbol to the registry as factor times the unit
+// expression definition, which may use prefixes, products ("N·m" or
+// "N*m"), quotients ("m/s"), powers ("m²" or "m^2") and "1", and only
+// the units already in the registry.  An empty definition makes a
+// dimensionless unit.
+//
+// e.g. r.Define("Wh", "W·h", 1)
+// e.g. r.Define("ft", "m", 0.3048)
+// e.g. r.Define("mph", "mi/h", 1)
+func (r *UnitRegistry) Define(symbol, definition string, factor float64) error {
+	def, err := r.resolve(definition)
+	if err != nil {
+		return err
+	}
+	def.factor *= factor
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.units[symbol] = def
+	return nil
+}
+
+// Known reports whether symbol is in the registry.  No symbol at all
+// is always known.
+func (r *UnitRegistry) Known(symbol string) bool {
+	_, ok := r.lookup(symbol)
+	return ok
+}
+
+func (r *UnitRegistry) lookup(symbol string) (unitDef, bool) {
+	if symbol == "" {
+		return unitDef{factor: 1}, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.units[symbol]
+	return def, ok
+}
+
+// splitSymbol splits s into an SI prefix and a known unit.  A known
+// unit wins over a prefix, and otherwise the longest prefix leaving a
+// known unit is used.
+func (r *UnitRegistry) splitSymbol(s string) (SIPrefix, unitDef, bool) {
+	if def, ok := r.lookup(s); ok {
+		return SIPrefix{}, def, true
+	}
+	var found SIPrefix
+	var def unitDef
+	ok := false
+	for _, p := range allSIPrefixes {
+		if p.Symbol == "" || len(p.Symbol) <= len(found.Symbol) ||
+			!strings.HasPrefix(s, p.Symbol) {
+			continue
+		}
+		if d, known := r.lookup(s[len(p.Symbol):]); known {
+			found, def, ok = p, d, true
+		}
+	}
+	return found, def, ok
+}
+
+// unitTerm is one factor of a unit expression, such as the "km²" of
+// "km²/s".
+type unitTerm struct {
+	symbol string
+	power  int
+}
+
+var superscripts = map[rune]byte{
+	'⁻': '-', '⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4',
+	'⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+}
+
+// parseUnitExpr splits a unit expression into its terms, with the
+// powers of the terms after a '/' negated.
+func parseUnitExpr(expr string) ([]unitTerm, error) {
+	var terms []unitTerm
+	for i, part := range strings.Split(expr, "/") {
+		sign := 1
+		if i > 0 {
+			sign = -1
+		}
+		factors := strings.FieldsFunc(part, func(r rune) bool {
+			return r == '·' || r == '⋅' || r == '*'
+		})
+		if len(factors) == 0 && expr != "" {
+			return nil, fmt.Errorf("invalid unit: %q", expr)
+		}
+		for _, factor := range factors {
+			t, err := parseUnitTerm(factor)
+			if err != nil {
+				return nil, err
+			}
+			t.power *= sign
+			terms = append(terms, t)
+		}
+	}
+	return terms, nil
+}
+
+func parseUnitTerm(s string) (unitTerm, error) {
+	symbol, power := s, ""
+	if i := strings.IndexByte(s, '^'); i >= 0 {
+		symbol, power = s[:i], s[i+1:]
+	} else {
+		// Collect trailing superscript digits.
+		runes := []rune(s)
+		end := len(runes)
+		for end > 0 {
+			if _, ok := superscripts[runes[end-1]]; !ok {
+				break
+			}
+			end--
+		}
+		var sb strings.Builder
+		for _, r := range runes[end:] {
+			sb.WriteByte(superscripts[r])
+		}
+		symbol, power = string(runes[:end]), sb.String()
+	}
+	if symbol == "" {
+		return unitTerm{}, fmt.Errorf("invalid unit: %q", s)
+	}
+	if power == "" {
+		return unitTerm{symbol, 1}, nil
+	}
+	p, err := strconv.Atoi(power)
+	if err != nil {
+		return unitTerm{}, fmt.Errorf("invalid unit power: %q", s)
+	}
+	return unitTerm{symbol, p}, nil
+}
+
+// resolve finds the definition of a unit expression in terms of base
+// units.  The offset of a unit only applies when it stands alone.
+func (r *UnitRegistry) resolve(expr string) (unitDef, error) {
+	terms, err := parseUnitExpr(expr)
+	if err != nil {
+		return unitDef{}, err
+	}
+	rv := unitDef{factor: 1, dim: map[string]int{}}
+	for _, t := range terms {
+		if t.symbol == "1" {
+			continue
+		}
+		prefix, def, ok := r.splitSymbol(t.symbol)
+		if !ok {
+			return unitDef{}, fmt.Errorf("unknown unit: %q", t.symbol)
+		}
+		rv.factor *= math.Pow(math.Pow10(prefix.Exponent)*def.factor, float64(t.power))
+		for base, p := range def.dim {
+			rv.dim[base] += p * t.power
+			if rv.dim[base] == 0 {
+				delete(rv.dim, base)
+			}
+		}
+		if len(terms) == 1 && t.power == 1 {
+			rv.offset = def.offset
+		}
+	}
+	return rv, nil
+}
+
+// sameDimension reports whether a and b are powers of the same base
+// units.
+func sameDimension(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDimension renders a dimension as a unit expression, such as
+// "g·m²/s³".
+func formatDimension(dim map[string]int) string {
+	var bases []string
+	for base := range dim {
+		bases = append(bases, base)
+	}
+	sort.Strings(bases)
+	var num, den []string
+	for _, base := range bases {
+		p := dim[base]
+		term := base
+		if p < 0 {
+			p = -p
+		}
+		if p != 1 {
+			term += "^" + strconv.Itoa(p)
+		}
+		if dim[base] > 0 {
+			num = append(num, term)
+		} else {
+			den = append(den, term)
+		}
+	}
+	rv := strings.Join(num, "·")
+	if rv == "" {
+		rv = "1"
+	}
+	if len(den) > 0 {
+		rv += "/" + strings.Join(den, "·")
+	}
+	return rv
+}
+
+// Convert converts value from one unit expression to another, such as
+// from "kW·h" to "J".  The units must have the same dimension.
+//
+// e.g. DefaultUnits.Convert(1.5, "kW·h", "J") -> (5.4e6, nil)
+// e.g. DefaultUnits.Convert(36, "km/h", "m/s") -> (10, nil)
+// e.g. DefaultUnits.Convert(20, "°C", "K") -> (293.15, nil)
+func (r *UnitRegistry) Convert(value float64, from, to string) (float64, error) {
+	f, err := r.resolve(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := r.resolve(to)
+	if err != nil {
+		return 0, err
+	}
+	if !sameDimension(f.dim, t.dim) {
+		return 0, fmt.Errorf("cannot convert %v (%v) to %v (%v)",
+			from, formatDimension(f.dim), to, formatDimension(t.dim))
+	}
+	return (value*f.factor + f.offset - t.offset) / t.factor, nil
+}
+
+// ConvertQuantity converts q to the unit expression to, so that it can
+// then be formatted with SI.
+//
+// e.g. DefaultUnits.ConvertQuantity(SIQuantity{3.6e6, "J"}, "W·h") -> ({1000, "W·h"}, nil)
+func (r *UnitRegistry) ConvertQuantity(q SIQuantity, to string) (SIQuantity, error) {
+	v, err := r.Convert(q.Value, q.Unit, to)
+	if err != nil {
+		return SIQuantity{}, err
+	}
+	return SIQuantity{v, to}, nil
+}
+
+// splitUnit splits the unit of an SI string into a prefix and a unit
+// expression.  The prefix of a unit expression is taken from its first
+// term, as in "kW·h", unless that term is raised to a power.
+func (r *UnitRegistry) splitUnit(s string) (SIPrefix, string, bool) {
+	if prefix, _, ok := r.splitSymbol(s); ok {
+		return prefix, s[len(prefix.Symbol):], true
+	}
+	terms, err := parseUnitExpr(s)
+	if err != nil || len(terms) == 0 {
+		return SIPrefix{}, "", false
+	}
+	if _, err := r.resolve(s); err != nil {
+		return SIPrefix{}, "", false
+	}
+	prefix, _, _ := r.splitSymbol(terms[0].symbol)
+	if terms[0].power != 1 || !strings.HasPrefix(s, terms[0].symbol) {
+		return SIPrefix{}, s, true
+	}
+	return prefix, s[len(prefix.Symbol):], true
+}
+
+// ParseSI parses an SI string back into the number and unit, like
+// ParseSI, but only accepts units in the registry, or expressions of
+// them such as "kW·h".  The prefixes "h", "da", "d" and "c" are
+// accepted along with those ComputeSI uses.
+//
+// e.g. DefaultUnits.ParseSI("5 m") -> (5, "m", nil)
+// e.g. DefaultUnits.ParseSI("3 Pa") -> (3, "Pa", nil)
+// e.g. DefaultUnits.ParseSI("2 min") -> (2, "min", nil)
+// e.g. DefaultUnits.ParseSI("4.7 µF") -> (4.7e-6, "F", nil)
+// e.g. DefaultUnits.ParseSI("2.5 kW·h") -> (2500, "W·h", nil)
+func (r *UnitRegistry) ParseSI(input string) (float64, string, error) {
+	found := siNumberRegex.FindStringSubmatch(input)
+	if len(found) != 3 {
+		return 0, "", errInvalid
+	}
+	prefix, unit, ok := r.splitUnit(found[2])
+	if !ok {
+		return 0, "", fmt.Errorf("unknown unit: %q", found[2])
+	}
+	v, err := parseSINumber(found[1], prefix.Exponent)
+	return v, unit, err
+}
@@ -0,0 +1,196 @@
+package humanize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitRegistryParseSI(t *testing.T) {
+	tests := []struct {
+		in    string
+		value float64
+		unit  string
+	}{
+		{"5 m", 5, "m"},
+		{"5 mm", 5e-3, "m"},
+		{"5m", 5, "m"},
+		{"3 Pa", 3, "Pa"},
+		{"3 kPa", 3e3, "Pa"},
+		{"2 hPa", 200, "Pa"},
+		{"2 min", 2, "min"},
+		{"2 ms", 2e-3, "s"},
+		{"2 mmol", 2e-3, "mol"},
+		{"2 cd", 2, "cd"},
+		{"2 cm", 0.02, "m"},
+		{"2 dam", 20, "m"},
+		{"3 dB", 3, "dB"},
+		{"4.7 µF", 4.7e-6, "F"},
+		{"1.5e3 Hz", 1500, "Hz"},
+		{"1.5e3 kHz", 1.5e6, "Hz"},
+		{"+2 V", 2, "V"},
+		{"-2.5 GW", -2.5e9, "W"},
+		{"42", 42, ""},
+		{"5 k", 5000, ""},
+		{"2.2345 pF", 2.2345e-12, "F"},
+	}
+
+	for _, test := range tests {
+		value, unit, err := DefaultUnits.ParseSI(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %v: %v", test.in, err)
+			continue
+		}
+		if value != test.value || unit != test.unit {
+			t.Errorf("On %v, got %v %q, wanted %v %q",
+				test.in, value, unit, test.value, test.unit)
+		}
+	}
+
+	for _, bad := range []string{"x1.21JW", "1.21 JW", "2 in"} {
+		if v, u, err := DefaultUnits.ParseSI(bad); err == nil {
+			t.Errorf("Expected error on %v, got %v %q", bad, v, u)
+		}
+	}
+}
+
+func TestUnitRegistryRegister(t *testing.T) {
+	r := NewUnitRegistry("m")
+	if r.Known("in") {
+		t.Errorf("Didn't expect to know inches")
+	}
+	r.Register("in", "ft")
+	if !r.Known("in") || !r.Known("") {
+		t.Errorf("Expected to know inches and no unit")
+	}
+	v, u, err := r.ParseSI("2 min")
+	if err != nil || v != 2e-3 || u != "in" {
+		t.Errorf("Expected 2 milli-inches, got %v %q %v", v, u, err)
+	}
+	r.Register("min")
+	v, u, err = r.ParseSI("2 min")
+	if err != nil || v != 2 || u != "min" {
+		t.Errorf("Expected 2 minutes, got %v %q %v", v, u, err)
+	}
+}
+
+func TestUnitRegistryParseSIExpressions(t *testing.T) {
+	tests := []struct {
+		in    string
+		value float64
+		unit  string
+	}{
+		{"2.5 kW·h", 2500, "W·h"},
+		{"2.5 kW*h", 2500, "W*h"},
+		{"36 km/h", 36000, "m/h"},
+		{"5 mm²", 5, "mm²"},
+		{"9.81 m/s^2", 9.81, "m/s^2"},
+		{"3 1/s", 3, "1/s"},
+	}
+
+	for _, test := range tests {
+		value, unit, err := DefaultUnits.ParseSI(test.in)
+		if err != nil {
+			t.Errorf("Error parsing %v: %v", test.in, err)
+			continue
+		}
+		if value != test.value || unit != test.unit {
+			t.Errorf("On %v, got %v %q, wanted %v %q",
+				test.in, value, unit, test.value, test.unit)
+		}
+	}
+
+	for _, bad := range []string{"2 kW·x", "2 W^x", "2 W/"} {
+		if v, u, err := DefaultUnits.ParseSI(bad); err == nil {
+			t.Errorf("Expected error on %v, got %v %q", bad, v, u)
+		}
+	}
+}
+
+func TestUnitRegistryConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		exp      float64
+	}{
+		{1.5, "kW·h", "J", 5.4e6},
+		{1.5, "kW·h", "Wh", 1500},
+		{3.6e6, "J", "kWh", 1},
+		{36, "km/h", "m/s", 10},
+		{1, "W", "J/s", 1},
+		{1, "N", "kg·m/s²", 1},
+		{1, "N", "kg*m*s^-2", 1},
+		{1, "L", "cm³", 1000},
+		{1, "ha", "m²", 1e4},
+		{2, "h", "min", 120},
+		{1, "t", "kg", 1000},
+		{1, "kB", "bit", 8000},
+		{1, "MHz", "1/s", 1e6},
+		{20, "°C", "K", 293.15},
+		{212, "°F", "°C", 100},
+		{-40, "°C", "°F", -40},
+		{1, "bar", "hPa", 1000},
+		{5, "", "", 5},
+	}
+
+	for _, test := range tests {
+		got, err := DefaultUnits.Convert(test.value, test.from, test.to)
+		if err != nil {
+			t.Errorf("Error converting %v %v to %v: %v", test.value, test.from, test.to, err)
+			continue
+		}
+		if math.Abs(got-test.exp) > 1e-9*math.Abs(test.exp) {
+			t.Errorf("Converting %v %v to %v, got %v, wanted %v",
+				test.value, test.from, test.to, got, test.exp)
+		}
+	}
+
+	for _, bad := range [][2]string{{"W", "J"}, {"m", "s"}, {"dB", ""}, {"W", "x"}, {"x", "W"}} {
+		if got, err := DefaultUnits.Convert(1, bad[0], bad[1]); err == nil {
+			t.Errorf("Expected error converting %v to %v, got %v", bad[0], bad[1], got)
+		}
+	}
+	_, err := DefaultUnits.Convert(1, "W", "J")
+	if err == nil || err.Error() != "cannot convert W (g·m^2/s^3) to J (g·m^2/s^2)" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestUnitRegistryDefine(t *testing.T) {
+	r := NewUnitRegistry("m", "s")
+	if err := r.Define("ft", "m", 0.3048); err != nil {
+		t.Fatalf("Error defining ft: %v", err)
+	}
+	if err := r.Define("in", "ft", 1.0/12); err != nil {
+		t.Fatalf("Error defining in: %v", err)
+	}
+	if err := r.Define("fps", "ft/s", 1); err != nil {
+		t.Fatalf("Error defining fps: %v", err)
+	}
+	if err := r.Define("bogus", "furlong", 1); err == nil {
+		t.Errorf("Expected error defining with an unknown unit")
+	}
+
+	got, err := r.Convert(1, "fps", "in/s")
+	if err != nil || math.Abs(got-12) > 1e-9 {
+		t.Errorf("Expected 12 in/s, got %v %v", got, err)
+	}
+}
+
+func TestUnitRegistryConvertQuantity(t *testing.T) {
+	q, err := DefaultUnits.ConvertQuantity(SIQuantity{3.6e9, "J"}, "W·h")
+	if err != nil {
+		t.Fatalf("Error converting: %v", err)
+	}
+	if got := SI(q.Value, q.Unit); got != "1 MW·h" {
+		t.Errorf("Expected 1 MW·h, got %v", got)
+	}
+
+	v, unit, err := DefaultUnits.ParseSI("2.5 kW·h")
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	q, err = DefaultUnits.ConvertQuantity(SIQuantity{v, unit}, "J")
+	if err != nil || q.String() != "9 MJ" {
+		t.Errorf("Expected 9 MJ, got %v %v", q, err)
+	}
+}
@@ -0,0 +1,121 @@
+package humanize
+
+import (
+	"math"
+	"strconv"
+)
+
+// unprefixedUnits are units that must never take an SI prefix, because
+// they are ratios, logarithmic, or on an offset scale.
+var unprefixedUnits = map[string]bool{
+	"%":   true,
+	"‰":   true,
+	"‱":   true,
+	"ppm": true,
+	"ppb": true,
+	"°":   true,
+	"°C":  true,
+	"°F":  true,
+	"dB":  true,
+	"dBm": true,
+	"dBi": true,
+	"dBA": true,
+	"Np":  true,
+	"pH":  true,
+}
+
+// Prefixable reports whether unit can take an SI prefix.  Percentages,
+// logarithmic units such as dB and temperatures in °C or °F can't.
+func Prefixable(unit string) bool {
+	return !unprefixedUnits[unit]
+}
+
+// fixedDecimals formats f*10^shift rounded to the given number of
+// decimal places, with no trailing zeros.
+func fixedDecimals(f float64, shift, decimals int) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	return stripTrailingZeros(roundDecimal(shiftDecimal(f, shift), decimals, RoundHalfEven))
+}
+
+// Percent formats a ratio as a percentage rounded to the given number
+// of decimal places, with no trailing zeros.
+//
+// e.g. Percent(0.1234, 1) -> 12.3%
+// e.g. Percent(1.5, 0) -> 150%
+func Percent(ratio float64, decimals int) string {
+	return fixedDecimals(ratio, 2, decimals) + "%"
+}
+
+// PerMille formats a ratio in parts per thousand, like Percent.
+//
+// e.g. PerMille(0.0123, 1) -> 12.3‰
+func PerMille(ratio float64, decimals int) string {
+	return fixedDecimals(ratio, 3, decimals) + "‰"
+}
+
+// Decibels formats a value in decibels rounded to the given number of
+// decimal places.
+//
+// e.g. Decibels(3.0103, 1) -> 3 dB
+func Decibels(db float64, decimals int) string {
+	return fixedDecimals(db, 0, decimals) + " dB"
+}
+
+// PowerRatioDecibels formats a ratio of two powers in decibels.
+//
+// e.g. PowerRatioDecibels(2, 1) -> 3 dB
+// e.g. PowerRatioDecibels(0.001, 0) -> -30 dB
+func PowerRatioDecibels(ratio float64, decimals int) string {
+	return Decibels(10*math.Log10(ratio), decimals)
+}
+
+// AmplitudeRatioDecibels formats a ratio of two amplitudes, such as
+// voltages, in decibels.
+//
+// e.g. AmplitudeRatioDecibels(2, 1) -> 6 dB
+func AmplitudeRatioDecibels(ratio float64, decimals int) string {
+	return Decibels(20*math.Log10(ratio), decimals)
+}
+
+// A TemperatureScale is a scale for Temperature.
+type TemperatureScale int
+
+// Temperature scales.
+const (
+	Celsius TemperatureScale = iota
+	Fahrenheit
+	Kelvin
+)
+
+// Symbol returns the symbol of the scale, such as "°C".
+func (s TemperatureScale) Symbol() string {
+	switch s {
+	case Fahrenheit:
+		return "°F"
+	case Kelvin:
+		return "K"
+	}
+	return "°C"
+}
+
+// Temperature formats a temperature on the given scale, rounded to
+// the given number of decimal places, with no prefix.
+//
+// e.g. Temperature(21.46, Celsius, 1) -> 21.5 °C
+// e.g. Temperature(-40, Fahrenheit, 0) -> -40 °F
+// e.g. Temperature(0.0015, Kelvin, 4) -> 0.0015 K
+func Temperature(value float64, scale TemperatureScale, decimals int) string {
+	return fixedDecimals(value, 0, decimals) + " " + scale.Symbol()
+}
+
+// PH formats a pH value, which is written after its symbol.
+//
+// e.g. PH(7.365, 2) -> pH 7.36
+func PH(value float64, decimals int) string {
+	return "pH " + fixedDecimals(value, 0, decimals)
+}
@@ -0,0 +1,56 @@
+package humanize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrefixable(t *testing.T) {
+	testList{
+		{"SI %", SI(1200, "%"), "1200 %"},
+		{"SI °C", SI(0.0015, "°C"), "0.0015 °C"},
+		{"SI dB", SI(-30000, "dB"), "-30000 dB"},
+		{"SI F", SI(1200, "F"), "1.2 kF"},
+		{"SIWithDigits %", SIWithDigits(1234.56, 1, "%"), "1234.6 %"},
+		{"SIWithOptions pH", SIWithOptions(7.3, "pH", SIOptions{Precision: SIFixed, Digits: 2}), "7.30 pH"},
+		{"SIWithOptions engineering", SIWithOptions(1200, "%", SIOptions{Engineering: true}), "1.2e3 %"},
+	}.validate(t)
+}
+
+func TestPercent(t *testing.T) {
+	testList{
+		{"12.34%", Percent(0.1234, 1), "12.3%"},
+		{"12.35%", Percent(0.1235, 1), "12.4%"},
+		{"150%", Percent(1.5, 0), "150%"},
+		{"50%", Percent(0.5, 2), "50%"},
+		{"0.07%", Percent(0.0007, 2), "0.07%"},
+		{"-5%", Percent(-0.05, 1), "-5%"},
+		{"exact", Percent(0.29, 0), "29%"},
+		{"NaN", Percent(math.NaN(), 1), "NaN%"},
+		{"‰", PerMille(0.0123, 1), "12.3‰"},
+	}.validate(t)
+}
+
+func TestDecibels(t *testing.T) {
+	testList{
+		{"3 dB", Decibels(3.0103, 1), "3 dB"},
+		{"-6.02 dB", Decibels(-6.0206, 2), "-6.02 dB"},
+		{"power 2", PowerRatioDecibels(2, 1), "3 dB"},
+		{"power 0.001", PowerRatioDecibels(0.001, 0), "-30 dB"},
+		{"power 0", PowerRatioDecibels(0, 0), "-Inf dB"},
+		{"amplitude 2", AmplitudeRatioDecibels(2, 1), "6 dB"},
+		{"amplitude 10", AmplitudeRatioDecibels(10, 1), "20 dB"},
+	}.validate(t)
+}
+
+func TestTemperature(t *testing.T) {
+	testList{
+		{"celsius", Temperature(21.46, Celsius, 1), "21.5 °C"},
+		{"fahrenheit", Temperature(-40, Fahrenheit, 0), "-40 °F"},
+		{"kelvin", Temperature(0.0015, Kelvin, 4), "0.0015 K"},
+		{"kelvin large", Temperature(5778, Kelvin, 0), "5778 K"},
+		{"symbol", Kelvin.Symbol(), "K"},
+		{"pH", PH(7.365, 2), "pH 7.36"},
+		{"pH 7", PH(7, 1), "pH 7"},
+	}.validate(t)
+}